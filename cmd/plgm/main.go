@@ -9,6 +9,8 @@ import (
 	"os"
 
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen/jsonschema"
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/db"
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/logger"
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/mongo"
@@ -23,6 +25,8 @@ func main() {
 	// 1. Setup Flags
 	configFlag := flag.String("config", "config.yaml", "Path to the configuration file")
 	versionFlag := flag.Bool("version", false, "Print version information and exit")
+	dashboardFlag := flag.Bool("dashboard", false, "Render a live full-screen dashboard instead of line-per-tick status logs")
+	failOnErrorRateFlag := flag.Float64("fail-on-error-rate", 0, "Exit non-zero if TotalErrors/TotalOps exceeds this fraction (e.g. 0.05), 0 disables")
 
 	// Custom Help Output
 	flag.Usage = func() {
@@ -44,14 +48,25 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_URI", "Connection URI")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_USERNAME", "Database User")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_PASSWORD", "Database Password (Recommended: Use Prompt)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_PASSWORD_SOURCE", "file:/path, stdin, awssm://<name>, gcpsm://..., vault://<path>#field")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_DIRECT_CONNECTION", "Force direct connection (true/false)")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_REPLICA_SET", "Replica Set name")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_READ_PREFERENCE", "nearest")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_AUTH_MECHANISM", "SASL mechanism (e.g. MONGODB-AWS, MONGODB-OIDC); empty uses username/password")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_OIDC_TOKEN_FILE", "MONGODB-OIDC machine-workload token file path")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_AWS_SESSION_TOKEN", "MONGODB-AWS temporary/STS session token")
 
 		fmt.Fprintf(os.Stderr, "\n [Workload Core]\n")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_DEFAULT_WORKLOAD", "Use built-in workload (true/false)")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_COLLECTIONS_PATH", "Path to collection JSON")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_COLLECTIONS_SCHEMA_PATH", "Path to $jsonSchema validator file/dir (overrides COLLECTIONS_PATH)")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_QUERIES_PATH", "Path to query JSON")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_SCENARIO_PATH", "Path to scripted scenarios YAML (see PLGM_SCENARIO_PERCENT)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_WATCH_WORKERS", "Dedicated long-running change-stream consumers (0 disables watch)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_WATCH_COLLECTION", "Narrow watch consumers to one collection (empty watches the whole database)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_WATCH_FULL_DOCUMENT", "default, required, whenAvailable, or updateLookup")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_WATCH_RESUME_TOKEN_FILE", "Persist watch consumers' resume tokens across restarts")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_WATCH_START_AT_OPERATION_TIME", "Unix seconds to replay the oplog from (ignored once a resume token exists)")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_DURATION", "Test duration (e.g. 60s, 5m)")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_CONCURRENCY", "Number of active workers")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_DOCUMENTS_COUNT", "Initial seed document count")
@@ -68,6 +83,10 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_DELETE_PERCENT", "% of ops that are DELETE")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_AGGREGATE_PERCENT", "% of ops that are AGGREGATE")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_TRANSACTION_PERCENT", "% of ops that are TRANSACTIONAL")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_CHANGE_STREAM_PERCENT", "% of ops that watch a CHANGE STREAM")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_BULK_WRITE_PERCENT", "% of ops that issue a single BULKWRITE of mixed models")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_SCENARIO_PERCENT", "% of ops that run a scripted scenario (requires PLGM_SCENARIO_PATH)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_WATCH_PERCENT", "Nominal share attributed to watch consumption (requires PLGM_WATCH_WORKERS)")
 
 		fmt.Fprintf(os.Stderr, "\n [Performance Optimization]\n")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_FIND_BATCH_SIZE", "Docs returned per cursor batch")
@@ -77,7 +96,20 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_RETRY_ATTEMPTS", "Retry attempts for failures")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_RETRY_BACKOFF_MS", "Wait time between retries (ms)")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PERCONALOAD_STATUS_REFRESH_RATE_SEC", "Status report interval (sec)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_BULK_WRITE_BATCH_SIZE", "WriteModels per BULKWRITE call (default per-collection override)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_BULK_WRITE_ORDERED", "Stop a BULKWRITE batch at its first failing model (true/false)")
 		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "GOMAXPROCS", "Go Runtime CPU limit")
+
+		fmt.Fprintf(os.Stderr, "\n [Metrics Export]\n")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_METRICS_LISTEN", "Prometheus /metrics listen address (e.g. :9090)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_STATSD_ADDR", "StatsD UDP endpoint (host:port)")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_METRICS_PREFIX", "Metric name prefix (default: plgm)")
+
+		fmt.Fprintf(os.Stderr, "\n [Reliability]\n")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_FAIL_ON_ERROR_RATE", "Exit non-zero if TotalErrors/TotalOps exceeds this fraction (e.g. 0.05), 0 disables")
+
+		fmt.Fprintf(os.Stderr, "\n [Display]\n")
+		fmt.Fprintf(os.Stderr, "  %-35s %s\n", "PLGM_DASHBOARD", "Render a live full-screen dashboard instead of line-per-tick status logs (true/false)")
 		fmt.Fprintf(os.Stderr, "\n")
 	}
 
@@ -109,6 +141,12 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to load application config:", err)
 	}
+	if *dashboardFlag {
+		appCfg.DashboardMode = true
+	}
+	if *failOnErrorRateFlag > 0 {
+		appCfg.FailOnErrorRate = *failOnErrorRateFlag
+	}
 
 	// --- Secure Credentials Logic ---
 
@@ -134,8 +172,9 @@ func main() {
 
 	// 3. Prompt for Password if missing
 	// Logic: If we have a username defined in config/prompt (overriding whatever is in URI),
-	// and no password is set for it, we must prompt.
-	if appCfg.ConnectionParams.Username != "" && appCfg.ConnectionParams.Password == "" {
+	// and no password is set for it, we must prompt. Skipped when PasswordSource
+	// is set, since ResolvePassword fetches the credential lazily at connect time.
+	if appCfg.ConnectionParams.Username != "" && appCfg.ConnectionParams.Password == "" && appCfg.ConnectionParams.PasswordSource == "" {
 		fmt.Printf("Enter Password for user '%s': ", appCfg.ConnectionParams.Username)
 		bytePassword, err := term.ReadPassword(int(os.Stdin.Fd()))
 		if err != nil {
@@ -146,7 +185,12 @@ func main() {
 	}
 
 	// --- Load Collections ---
-	collectionsCfg, err := config.LoadCollections(appCfg.CollectionsPath, appCfg.DefaultWorkload)
+	var collectionsCfg *config.CollectionsFile
+	if appCfg.CollectionsSchemaPath != "" {
+		collectionsCfg, err = jsonschema.LoadCollections(appCfg.CollectionsSchemaPath)
+	} else {
+		collectionsCfg, err = config.LoadCollections(appCfg.CollectionsPath, appCfg.DefaultWorkload)
+	}
 	if err != nil {
 		log.Fatal("Failed to load collections:", err)
 	}
@@ -192,7 +236,7 @@ func main() {
 	stats.PrintConfiguration(appCfg, collectionsCfg.Collections, version)
 
 	// --- Connect to DB ---
-	conn, err := db.Connect(ctx, appCfg, dbName)
+	conn, err := db.Connect(ctx, appCfg, dbName, collectionsCfg.Collections)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -217,11 +261,14 @@ func main() {
 	}
 
 	// --- Seed documents (initial dataset) ---
+	datagen.InitRefRegistry(appCfg.InsertCacheSize)
 	if !appCfg.SkipSeed {
 		if appCfg.DocumentsCount > 0 {
 			// NOTE: Logging is handled inside InsertRandomDocuments,
 			// but we can add a high-level log here if we want.
-			for _, col := range collectionsCfg.Collections {
+			// Seed in reference-dependency order so a "ref" provider field
+			// always has a parent collection's keys to sample from.
+			for _, col := range config.OrderByReferenceDependency(collectionsCfg.Collections) {
 				if err := mongo.InsertRandomDocuments(ctx, conn.Database, col, appCfg.DocumentsCount, appCfg); err != nil {
 					log.Fatal(err)
 				}
@@ -5,46 +5,213 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/Percona-Lab/percona-load-generator-mongodb/resources"
+	"gopkg.in/yaml.v3"
 )
 
 type CollectionField struct {
-	Type      string                     `json:"type"`
-	Provider  string                     `json:"provider,omitempty"`
-	MaxLength int                        `json:"maxLength,omitempty"`
-	MinLength int                        `json:"minLength,omitempty"`
-	Min       *int                       `json:"min,omitempty"`
-	Max       *int                       `json:"max,omitempty"`
-	Enum      []string                   `json:"enum,omitempty"`
-	Items     *CollectionField           `json:"items,omitempty"`
-	Fields    map[string]CollectionField `json:"fields,omitempty"`
-	ArraySize int                        `json:"arraySize,omitempty"`
+	Type         string                     `json:"type" yaml:"type"`
+	Provider     string                     `json:"provider,omitempty" yaml:"provider,omitempty"`
+	MaxLength    int                        `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinLength    int                        `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	Min          *int                       `json:"min,omitempty" yaml:"min,omitempty"`
+	Max          *int                       `json:"max,omitempty" yaml:"max,omitempty"`
+	Enum         []string                   `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Items        *CollectionField           `json:"items,omitempty" yaml:"items,omitempty"`
+	Fields       map[string]CollectionField `json:"fields,omitempty" yaml:"fields,omitempty"`
+	ArraySize    int                        `json:"arraySize,omitempty" yaml:"arraySize,omitempty"`
+	Distribution *Distribution              `json:"distribution,omitempty" yaml:"distribution,omitempty"`
+
+	// RefCollection/RefField/RefStrategy configure the "ref" provider: the
+	// field's value is sampled from keys already seeded into RefCollection's
+	// RefField instead of generated independently, so documents form a
+	// realistic graph (e.g. order.user_id -> an _id that exists in users).
+	RefCollection string `json:"refCollection,omitempty" yaml:"refCollection,omitempty"`
+	RefField      string `json:"refField,omitempty" yaml:"refField,omitempty"`
+	RefStrategy   string `json:"refStrategy,omitempty" yaml:"refStrategy,omitempty"` // uniform (default), zipf, recent
+
+	// RefCardinality selects how many keys a "ref" provider field samples:
+	// "one" (default) samples a single value, same as before this existed;
+	// "many" samples ArraySize values (falling back to 3 when ArraySize is
+	// 0) into a slice, for fields like
+	// passengers[].ticket_number that are reused by several child documents.
+	RefCardinality string `json:"refCardinality,omitempty" yaml:"refCardinality,omitempty"`
+
+	// Pattern, when set, generates the field with faker.Regex(Pattern)
+	// instead of the type-based default (used for $jsonSchema "pattern").
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+
+	// Encrypt marks this field as client-side-encrypted (CSFLE) or, with
+	// QueryType set, Queryable-Encryption-encrypted. nil means the field is
+	// generated/stored in the clear, same as before this existed.
+	Encrypt *EncryptSpec `json:"encrypt,omitempty" yaml:"encrypt,omitempty"`
+}
+
+// EncryptSpec configures how one field is encrypted. See
+// AppConfig.CSFLE/internal/db's schema/encryptedFieldsMap builders for how
+// this turns into the driver's AutoEncryption options.
+type EncryptSpec struct {
+	// Algorithm is the CSFLE algorithm: "deterministic" (queryable via
+	// equality, but same plaintext always encrypts to the same ciphertext)
+	// or "random" (stronger, but not queryable at all). Ignored when
+	// QueryType is set, since Queryable Encryption picks its own algorithm.
+	Algorithm string `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	// KeyAltName names the data key this field is encrypted under.
+	// EnsureDataKeys creates one data key per distinct KeyAltName on first
+	// run and reuses it on every run after (idempotent by KeyAltName).
+	KeyAltName string `json:"keyAltName,omitempty" yaml:"keyAltName,omitempty"`
+	// QueryType switches this field to Queryable Encryption instead of
+	// CSFLE: "equality" or "range". Empty keeps the CSFLE Algorithm path.
+	QueryType string `json:"queryType,omitempty" yaml:"queryType,omitempty"`
+	// Contention tunes a QE field's contention factor (higher spreads
+	// equality/range queries across more insertion padding, trading read
+	// throughput for write concurrency). Unset uses the driver's default.
+	Contention *int64 `json:"contention,omitempty" yaml:"contention,omitempty"`
+	// RangeMin/RangeMax bound a QueryType: "range" field's encrypted range
+	// index (e.g. 0/1440 for a duration_minutes-style field).
+	RangeMin *int `json:"rangeMin,omitempty" yaml:"rangeMin,omitempty"`
+	RangeMax *int `json:"rangeMax,omitempty" yaml:"rangeMax,omitempty"`
+}
+
+// Distribution skews a numeric field away from uniform sampling so the
+// generated/queried key space looks like a real workload (hot keys, long
+// tails) instead of every value being equally likely. Kind selects which
+// parameters below apply; unset or "uniform" keeps the old rng.Intn behavior.
+type Distribution struct {
+	Kind        string  `json:"kind,omitempty" yaml:"kind,omitempty"` // uniform (default), zipf, pareto, normal, hotspot
+	S           float64 `json:"s,omitempty" yaml:"s,omitempty"`       // zipf/pareto exponent
+	V           float64 `json:"v,omitempty" yaml:"v,omitempty"`       // zipf offset
+	Mean        float64 `json:"mean,omitempty" yaml:"mean,omitempty"`
+	StdDev      float64 `json:"stddev,omitempty" yaml:"stddev,omitempty"`
+	HotFraction float64 `json:"hotFraction,omitempty" yaml:"hotFraction,omitempty"` // hotspot: fraction of the range considered "hot"
+	HotWeight   float64 `json:"hotWeight,omitempty" yaml:"hotWeight,omitempty"`     // hotspot: probability of sampling from the hot range
 }
 
 type IndexDefinition struct {
-	Keys map[string]interface{} `json:"keys"`
+	Keys map[string]interface{} `json:"keys" yaml:"keys"`
+}
+
+// ZoneConfig assigns a key range on a sharded collection to a named zone,
+// and the shards that zone is backed by.
+type ZoneConfig struct {
+	Name   string                 `json:"name" yaml:"name"`
+	Shards []string               `json:"shards" yaml:"shards"`
+	Min    map[string]interface{} `json:"min" yaml:"min"`
+	Max    map[string]interface{} `json:"max" yaml:"max"`
 }
 
 // ShardConfig defines how a collection should be sharded.
 type ShardConfig struct {
-	Key    map[string]interface{} `json:"key"`
-	Unique bool                   `json:"unique,omitempty"`
+	Key    map[string]interface{} `json:"key" yaml:"key"`
+	Unique bool                   `json:"unique,omitempty" yaml:"unique,omitempty"`
+
+	// NumInitialChunks pre-splits a hashed-shard-key collection into N
+	// chunks at creation time (passed straight through to shardCollection)
+	// instead of letting everything land on one chunk during ramp-up.
+	NumInitialChunks int `json:"numInitialChunks,omitempty" yaml:"numInitialChunks,omitempty"`
+	// PresplitPoints are explicit split points, applied via the "split"
+	// admin command after shardCollection succeeds. Takes precedence over
+	// NumInitialChunks.
+	PresplitPoints []map[string]interface{} `json:"presplitPoints,omitempty" yaml:"presplitPoints,omitempty"`
+	// Zones pins ranges of the shard key to specific shards.
+	Zones []ZoneConfig `json:"zones,omitempty" yaml:"zones,omitempty"`
 }
 
 type CollectionDefinition struct {
-	DatabaseName string                     `json:"database"`
-	Name         string                     `json:"collection"`
-	Fields       map[string]CollectionField `json:"fields"`
-	Indexes      []IndexDefinition          `json:"indexes,omitempty"`
-	ShardConfig  *ShardConfig               `json:"shardConfig,omitempty"`
+	DatabaseName string                     `json:"database" yaml:"database"`
+	Name         string                     `json:"collection" yaml:"collection"`
+	Fields       map[string]CollectionField `json:"fields" yaml:"fields"`
+	Indexes      []IndexDefinition          `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	ShardConfig  *ShardConfig               `json:"shardConfig,omitempty" yaml:"shardConfig,omitempty"`
+
+	// WorkloadKind selects a registered workloads.Workload (e.g. "flights",
+	// "ecommerce_orders") to generate this collection's documents/updates
+	// instead of the generic per-field generator. Empty uses the generic
+	// generator, unless DefaultWorkload is set and Name == "flights" (the
+	// pre-WorkloadKind behavior, kept for collections authored before this
+	// field existed). See internal/workloads.Registered for the built-in list.
+	WorkloadKind string `json:"workloadKind,omitempty" yaml:"workloadKind,omitempty"`
+
+	// BulkWriteBatchSize/BulkWriteOrdered configure this collection's
+	// "bulkWrite" operation: how many WriteModels go into one BulkWrite
+	// call, and whether the driver stops at the first failing model
+	// (ordered) or keeps going and reports every failure (unordered). A
+	// nil/zero value falls back to AppConfig.BulkWriteBatchSize/
+	// BulkWriteOrdered (see *int Min/Max above for the same unset-vs-zero
+	// convention).
+	BulkWriteBatchSize int   `json:"bulkWriteBatchSize,omitempty" yaml:"bulkWriteBatchSize,omitempty"`
+	BulkWriteOrdered   *bool `json:"bulkWriteOrdered,omitempty" yaml:"bulkWriteOrdered,omitempty"`
 }
 
 type CollectionsFile struct {
 	Collections []CollectionDefinition `json:"collections"`
 }
 
+// OrderByReferenceDependency returns collections reordered so that, for
+// every field with a RefCollection set, the referenced collection appears
+// before the one declaring the reference - parents are seeded before
+// children, so InsertRandomDocuments never has to leave a "ref" provider
+// field without any keys to sample. Collections involved in a reference
+// cycle keep their relative input order, appended after everything that
+// could be resolved; a reference to a name not present in collections is
+// ignored for ordering.
+func OrderByReferenceDependency(collections []CollectionDefinition) []CollectionDefinition {
+	byName := make(map[string]CollectionDefinition, len(collections))
+	for _, col := range collections {
+		byName[col.Name] = col
+	}
+
+	// dependsOn[x] = set of collection names x must be seeded after.
+	dependsOn := make(map[string]map[string]bool, len(collections))
+	for _, col := range collections {
+		dependsOn[col.Name] = map[string]bool{}
+		for _, fdef := range col.Fields {
+			if fdef.RefCollection != "" && fdef.RefCollection != col.Name {
+				if _, ok := byName[fdef.RefCollection]; ok {
+					dependsOn[col.Name][fdef.RefCollection] = true
+				}
+			}
+		}
+	}
+
+	var ordered []CollectionDefinition
+	placed := make(map[string]bool, len(collections))
+
+	for len(placed) < len(collections) {
+		progressed := false
+		for _, col := range collections {
+			if placed[col.Name] {
+				continue
+			}
+			ready := true
+			for dep := range dependsOn[col.Name] {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, col)
+				placed[col.Name] = true
+				progressed = true
+			}
+		}
+		if !progressed {
+			// A cycle remains: append everything left, in their original
+			// order, rather than looping forever.
+			for _, col := range collections {
+				if !placed[col.Name] {
+					ordered = append(ordered, col)
+					placed[col.Name] = true
+				}
+			}
+		}
+	}
+
+	return ordered
+}
+
 // LoadCollections attempts to load from disk. If the path is not found,
 // it falls back to the embedded default.json.
 // LoadCollections filters files based on the 'loadDefault' flag.
@@ -79,11 +246,11 @@ func LoadCollections(path string, loadDefault bool) (*CollectionsFile, error) {
 		}
 
 		for _, entry := range entries {
-			if entry.IsDir() || !strings.HasSuffix(strings.ToLower(entry.Name()), ".json") {
+			if entry.IsDir() || !isDataFile(entry.Name()) {
 				continue
 			}
 
-			isDefault := strings.EqualFold(entry.Name(), "default.json")
+			isDefault := isDefaultFile(entry.Name())
 
 			if loadDefault {
 				if !isDefault {
@@ -120,7 +287,11 @@ func loadEmbeddedCollection(embedPath string) (*CollectionsFile, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read embedded file %s: %w", embedPath, err)
 	}
-	return parseCollectionsBytes(b)
+	b, err = renderTemplate(embedPath, b)
+	if err != nil {
+		return nil, err
+	}
+	return parseCollectionsBytes(embedPath, b)
 }
 
 func loadCollectionsFromFile(path string) (*CollectionsFile, error) {
@@ -128,11 +299,28 @@ func loadCollectionsFromFile(path string) (*CollectionsFile, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read collections file: %w", err)
 	}
-	return parseCollectionsBytes(b)
+	b, err = renderTemplate(path, b)
+	if err != nil {
+		return nil, err
+	}
+	return parseCollectionsBytes(path, b)
 }
 
-// Common parsing logic for both Disk and Embed
-func parseCollectionsBytes(b []byte) (*CollectionsFile, error) {
+// Common parsing logic for both Disk and Embed. name is used only to
+// decide JSON vs YAML based on its extension.
+func parseCollectionsBytes(name string, b []byte) (*CollectionsFile, error) {
+	if isYAMLFile(name) {
+		var wrapped CollectionsFile
+		if err := yaml.Unmarshal(b, &wrapped); err == nil && len(wrapped.Collections) > 0 {
+			return &wrapped, nil
+		}
+		var arr []CollectionDefinition
+		if err := yaml.Unmarshal(b, &arr); err == nil && len(arr) > 0 {
+			return &CollectionsFile{Collections: arr}, nil
+		}
+		return nil, fmt.Errorf("invalid collections format")
+	}
+
 	var wrapped CollectionsFile
 	if err := json.Unmarshal(b, &wrapped); err == nil && len(wrapped.Collections) > 0 {
 		return &wrapped, nil
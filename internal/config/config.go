@@ -5,7 +5,7 @@ import (
 	"os"
 	"strconv"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 )
 
 // AppConfig holds the application's runtime configuration.
@@ -15,7 +15,15 @@ type AppConfig struct {
 	DefaultWorkload bool `yaml:"default_workload"`
 
 	CollectionsPath string `yaml:"collections_path"`
-	QueriesPath     string `yaml:"queries_path"`
+	// CollectionsSchemaPath, when set, derives collection fields from a
+	// $jsonSchema validator file/dir instead of CollectionsPath's
+	// hand-written YAML/JSON (see internal/datagen/jsonschema).
+	CollectionsSchemaPath string `yaml:"collections_schema_path"`
+	QueriesPath           string `yaml:"queries_path"`
+	// ScenarioPath points at a YAML file describing scripted scenarios
+	// (see internal/scenario.LoadScenarios). Empty disables the scenario
+	// op kind regardless of ScenarioPercent.
+	ScenarioPath    string `yaml:"scenario_path"`
 	DropCollections bool   `yaml:"drop_collections"`
 	SkipSeed        bool   `yaml:"skip_seed"`
 	DocumentsCount  int    `yaml:"documents_count"`
@@ -29,11 +37,77 @@ type AppConfig struct {
 	AggregatePercent   int    `yaml:"aggregate_percent"`
 	TransactionPercent int    `yaml:"transaction_percent"`
 	BulkInsertPercent  int    `yaml:"bulk_insert_percent"`
-	InsertBatchSize    int    `yaml:"insert_batch_size"`
-	SeedBatchSize      int    `yaml:"seed_batch_size"`
-	UseTransactions    bool   `yaml:"use_transactions"`
-	MaxTransactionOps  int    `yaml:"max_transaction_ops"`
-	DebugMode          bool   `yaml:"debug_mode"`
+	// ChangeStreamPercent is the share of ops that open a change stream
+	// against a random collection instead of running CRUD (see
+	// internal/mongo's changeStream worker).
+	ChangeStreamPercent int `yaml:"change_stream_percent"`
+	// FindPaginatedPercent is the share of ops that scroll through a
+	// collection page-by-page via a keyset cursor token instead of
+	// running a single fixed-limit find (see internal/cursortoken).
+	FindPaginatedPercent int `yaml:"find_paginated_percent"`
+	// BulkWritePercent is the share of ops that issue a single BulkWrite
+	// of mixed insert/update/delete WriteModels instead of one op at a
+	// time. BulkWriteBatchSize/BulkWriteOrdered are the run-wide defaults;
+	// a CollectionDefinition may override either (see collections.go).
+	BulkWritePercent   int  `yaml:"bulk_write_percent"`
+	BulkWriteBatchSize int  `yaml:"bulk_write_batch_size"`
+	BulkWriteOrdered   bool `yaml:"bulk_write_ordered"`
+	// ScenarioPercent is the share of ops that run a scripted
+	// internal/scenario.Scenario (an ordered sequence of steps with
+	// variable capture/reuse and optional per-step assertions) instead of
+	// a single random op. Has no effect if ScenarioPath is unset.
+	ScenarioPercent int `yaml:"scenario_percent"`
+	// WatchPercent is the share of the op mix nominally spent on "watch"
+	// (it dilutes the other percentages the same way ScenarioPercent
+	// does) - but unlike every other percent field, it doesn't drive any
+	// per-iteration work itself. The actual change-stream consumption runs
+	// continuously in WatchWorkers dedicated goroutines (see
+	// internal/mongo/watch.go), started alongside the CRUD workers rather
+	// than picked per-op. Has no effect if WatchWorkers is 0.
+	WatchPercent int `yaml:"watch_percent"`
+	// WatchWorkers is how many long-running change-stream consumers run
+	// for the whole workload duration, each opening its own
+	// Database.Watch()/Collection.Watch() cursor (scoped by
+	// WatchCollection) and recording per-event end-to-end latency. 0
+	// disables watch consumption entirely.
+	WatchWorkers int `yaml:"watch_workers"`
+	// WatchCollection narrows every watch consumer to one collection's
+	// change stream instead of the whole database's.
+	WatchCollection string `yaml:"watch_collection"`
+	// WatchFullDocument selects a watch consumer's fullDocument mode:
+	// "default", "required", "whenAvailable", or "updateLookup".
+	WatchFullDocument string `yaml:"watch_full_document"`
+	// WatchMatchOperationTypes, when non-empty, adds a $match stage
+	// restricting each watch consumer's stream to these operationType
+	// values (e.g. ["insert", "update"]) instead of every change.
+	WatchMatchOperationTypes []string `yaml:"watch_match_operation_types"`
+	// WatchStartAtOperationTime, when set, opens each watch consumer's
+	// first cursor at this Unix timestamp (seconds) for point-in-time
+	// replay instead of starting from "now". Ignored once a resume token
+	// exists for that consumer, the same precedence the driver itself
+	// gives SetResumeAfter over SetStartAtOperationTime.
+	WatchStartAtOperationTime *int64 `yaml:"watch_start_at_operation_time"`
+	// WatchResumeTokenFile, when set, persists each watch consumer's last
+	// seen resume token to this file so a process restart continues from
+	// where it left off instead of replaying or missing events.
+	WatchResumeTokenFile string `yaml:"watch_resume_token_file"`
+	InsertBatchSize      int    `yaml:"insert_batch_size"`
+	SeedBatchSize        int    `yaml:"seed_batch_size"`
+	UseTransactions      bool   `yaml:"use_transactions"`
+	MaxTransactionOps    int    `yaml:"max_transaction_ops"`
+	DebugMode            bool   `yaml:"debug_mode"`
+
+	// MaxTxnRetries bounds how many times runTransaction retries a
+	// transaction whose error carries the TransientTransactionError or
+	// UnknownTransactionCommitResult label, with exponential backoff plus
+	// jitter between attempts (capped by ctx's own deadline).
+	MaxTxnRetries int `yaml:"max_txn_retries"`
+	// TxnReadConcern/TxnWriteConcern tune the session's transaction
+	// options (e.g. "majority", "snapshot", "local") so users can
+	// reproduce contention/WriteConflict behavior at different isolation
+	// levels instead of always running with the driver's defaults.
+	TxnReadConcern  string `yaml:"txn_read_concern"`
+	TxnWriteConcern string `yaml:"txn_write_concern"`
 
 	FindBatchSize         int   `yaml:"find_batch_size"`
 	FindLimit             int64 `yaml:"find_limit"`
@@ -44,14 +118,96 @@ type AppConfig struct {
 	RetryAttempts         int   `yaml:"retry_attempts"`
 	RetryBackoffMs        int   `yaml:"retry_backoff_ms"`
 
+	// ChangeStreamWindowMs/ChangeStreamMaxEvents bound how long a single
+	// changeStream worker iteration keeps a cursor open: it stops after
+	// whichever limit is hit first. ChangeStreamBatchSize sets the
+	// driver's getMore batch size for that cursor.
+	ChangeStreamWindowMs  int   `yaml:"change_stream_window_ms"`
+	ChangeStreamMaxEvents int   `yaml:"change_stream_max_events"`
+	ChangeStreamBatchSize int32 `yaml:"change_stream_batch_size"`
+
+	// FailOnErrorRate, when non-zero, makes the workload return an error
+	// (instead of exiting 0) once TotalErrors/TotalOps across the run
+	// exceeds this fraction (e.g. 0.05 for 5%). 0 disables the check.
+	FailOnErrorRate float64 `yaml:"fail_on_error_rate"`
+
+	// DashboardMode swaps the default line-per-tick Monitor log for
+	// Collector.Dashboard's full-screen, redraw-in-place view. Meant for an
+	// interactive TTY; leave it off for piped/CI output. Settable via the
+	// --dashboard flag as well as this field/env var.
+	DashboardMode bool `yaml:"dashboard_mode"`
+
+	// RandomSeed, when non-zero, makes data generation reproducible: the
+	// same seed always produces the same documents, insert cache contents
+	// and query filter values across runs.
+	RandomSeed int64 `yaml:"random_seed"`
+
+	// MetricsListen, when set, starts a Prometheus "/metrics" HTTP endpoint
+	// on the given address (e.g. ":9090"). StatsdAddr, when set, pushes the
+	// same operation counters/latencies as StatsD lines to a UDP endpoint.
+	MetricsListen string `yaml:"metrics_listen"`
+	StatsdAddr    string `yaml:"statsd_addr"`
+	MetricsPrefix string `yaml:"metrics_prefix"`
+
 	ConnectionParams ConnectionParams       `yaml:"connection_params"`
 	CustomParamsMap  map[string]interface{} `yaml:"custom_params"`
 	Debug            bool                   `yaml:"debug"`
+
+	// CSFLE enables Client-Side Field-Level Encryption (or, with
+	// QueryableEncryption, Queryable Encryption) for collections whose
+	// fields set CollectionField.Encrypt. Zero value keeps encryption off.
+	CSFLE CSFLEConfig `yaml:"csfle"`
+}
+
+// CSFLEConfig is AppConfig's top-level CSFLE/Queryable Encryption block. See
+// internal/db's schema/encryptedFieldsMap builders for how this and each
+// field's CollectionField.Encrypt become the driver's AutoEncryption options.
+type CSFLEConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// KMSProvider selects which KMS backs the key vault's master key(s):
+	// "aws", "gcp", "azure", "local", or "kmip". KMSProviders carries that
+	// provider's credentials/config, passed through to
+	// options.AutoEncryption().SetKmsProviders verbatim (e.g.
+	// KMSProviders["aws"] = map[string]interface{}{"accessKeyId": ...,
+	// "secretAccessKey": ...}).
+	KMSProvider  string                            `yaml:"kms_provider"`
+	KMSProviders map[string]map[string]interface{} `yaml:"kms_providers"`
+
+	// KeyVaultNamespace is "<database>.<collection>" for the data-key
+	// collection (e.g. "encryption.__keyVault").
+	KeyVaultNamespace string `yaml:"key_vault_namespace"`
+
+	// SchemaMap is a hand-authored CSFLE $jsonSchema per collection
+	// namespace, passed straight through to
+	// options.AutoEncryption().SetSchemaMap. Leave unset to have
+	// internal/db derive one from each collection's Fields[...].Encrypt
+	// instead of requiring it be authored by hand.
+	SchemaMap map[string]interface{} `yaml:"schema_map"`
+
+	// EncryptedFieldsMap is the Queryable Encryption equivalent of
+	// SchemaMap, passed through to
+	// options.AutoEncryption().SetEncryptedFieldsMap. Also derivable from
+	// Fields[...].Encrypt when QueryableEncryption is set and this is left
+	// unset.
+	EncryptedFieldsMap map[string]interface{} `yaml:"encrypted_fields_map"`
+
+	// QueryableEncryption switches the derived (non-hand-authored) map from
+	// CSFLE's SchemaMap to Queryable Encryption's EncryptedFieldsMap, so
+	// equality/range-queryable fields (QueryType) are usable instead of
+	// only CSFLE's deterministic/random algorithms.
+	QueryableEncryption bool `yaml:"queryable_encryption"`
 }
 
 type ConnectionParams struct {
-	Username               string `yaml:"username"`
-	Password               string `yaml:"-"`
+	Username string `yaml:"username"`
+	Password string `yaml:"-"`
+	// PasswordSource, when set, tells ResolvePassword where to fetch the
+	// password from instead of using Password directly: "file:/path",
+	// "stdin", "awssm://<name>", "gcpsm://projects/.../secrets/.../versions/latest",
+	// or "vault://<path>#field". Empty (or "env") keeps the Password/
+	// PLGM_PASSWORD back-compat behavior.
+	PasswordSource         string `yaml:"password_source"`
 	AuthSource             string `yaml:"auth_source"`
 	DirectConnection       bool   `yaml:"direct_connection"`
 	ConnectionTimeout      int    `yaml:"connection_timeout"`
@@ -61,6 +217,50 @@ type ConnectionParams struct {
 	MaxIdleTime            int    `yaml:"max_idle_time"`
 	ReplicaSetName         string `yaml:"replicaset_name"`
 	ReadPreference         string `yaml:"read_preference"`
+
+	// AuthMechanism selects the SASL mechanism the driver authenticates
+	// with (e.g. "SCRAM-SHA-256", "MONGODB-AWS", "MONGODB-OIDC"). Empty
+	// keeps the historical behavior: Username/password (resolved above)
+	// baked into the connection URI, letting the server pick the default
+	// mechanism.
+	AuthMechanism string `yaml:"auth_mechanism"`
+	// AuthMechanismProperties carries mechanism-specific key/value pairs
+	// passed straight through to options.Credential (e.g. "AWS_ROLE_ARN",
+	// "ENVIRONMENT": "azure"/"gcp", "TOKEN_RESOURCE" for MONGODB-OIDC's
+	// Azure/GCP identity-provider flows).
+	AuthMechanismProperties map[string]string `yaml:"auth_mechanism_properties"`
+	// AWSSessionToken, like Password, is env/flag-only (never persisted to
+	// YAML): it's folded into AuthMechanismProperties as "AWS_SESSION_TOKEN"
+	// for MONGODB-AWS temporary/STS credentials.
+	AWSSessionToken string `yaml:"-"`
+	// OIDCTokenFile is the MONGODB-OIDC machine-workload flow: a file path
+	// whose (trimmed) contents are used as the access token. It's re-read on
+	// every driver callback invocation, so external token rotation and a
+	// driver-triggered ReauthenticationRequired re-auth both see the latest
+	// token without this process caching a stale one itself.
+	OIDCTokenFile string `yaml:"oidc_token_file"`
+	// OIDCHumanCommand is the MONGODB-OIDC human-workload flow: an external
+	// command (an IdP CLI login helper) invoked on every driver callback;
+	// its trimmed stdout is used as the access token. Empty disables the
+	// human flow, leaving OIDCTokenFile (if set) as the only callback.
+	OIDCHumanCommand string `yaml:"oidc_human_command"`
+}
+
+// String redacts Password/AWSSessionToken so fmt's "%v"/"%+v" (e.g.
+// debug-logging an AppConfig) never prints a credential, resolved or not.
+func (cp ConnectionParams) String() string {
+	redacted := cp
+	if redacted.Password != "" {
+		redacted.Password = "***redacted***"
+	}
+	if redacted.AWSSessionToken != "" {
+		redacted.AWSSessionToken = "***redacted***"
+	}
+	return fmt.Sprintf("{Username:%s Password:%s PasswordSource:%s AuthSource:%s DirectConnection:%t ConnectionTimeout:%d ServerSelectionTimeout:%d MaxPoolSize:%d MinPoolSize:%d MaxIdleTime:%d ReplicaSetName:%s ReadPreference:%s AuthMechanism:%s AWSSessionToken:%s OIDCTokenFile:%s OIDCHumanCommand:%s}",
+		redacted.Username, redacted.Password, redacted.PasswordSource, redacted.AuthSource, redacted.DirectConnection,
+		redacted.ConnectionTimeout, redacted.ServerSelectionTimeout, redacted.MaxPoolSize, redacted.MinPoolSize, redacted.MaxIdleTime,
+		redacted.ReplicaSetName, redacted.ReadPreference, redacted.AuthMechanism, redacted.AWSSessionToken,
+		redacted.OIDCTokenFile, redacted.OIDCHumanCommand)
 }
 
 func LoadAppConfig(path string) (*AppConfig, error) {
@@ -78,7 +278,7 @@ func LoadAppConfig(path string) (*AppConfig, error) {
 	overriddenStats := applyEnvOverrides(cfg)
 
 	// Normalize based on what was overridden
-	normalizePercentages(cfg, overriddenStats)
+	applyOperationMix(cfg, overriddenStats)
 
 	applyDefaults(cfg)
 
@@ -117,6 +317,24 @@ func applyDefaults(cfg *AppConfig) {
 	if cfg.MaxTransactionOps <= 0 {
 		cfg.MaxTransactionOps = 3
 	}
+	if cfg.MaxTxnRetries <= 0 {
+		cfg.MaxTxnRetries = 3
+	}
+	if cfg.MetricsPrefix == "" {
+		cfg.MetricsPrefix = "plgm"
+	}
+	if cfg.ChangeStreamWindowMs <= 0 {
+		cfg.ChangeStreamWindowMs = 2000
+	}
+	if cfg.ChangeStreamMaxEvents <= 0 {
+		cfg.ChangeStreamMaxEvents = 50
+	}
+	if cfg.ChangeStreamBatchSize <= 0 {
+		cfg.ChangeStreamBatchSize = 10
+	}
+	if cfg.BulkWriteBatchSize <= 0 {
+		cfg.BulkWriteBatchSize = 10
+	}
 }
 
 // applyEnvOverrides updates the config from ENV vars and returns a map
@@ -132,6 +350,9 @@ func applyEnvOverrides(cfg *AppConfig) map[string]bool {
 	if v := os.Getenv("PLGM_PASSWORD"); v != "" {
 		cfg.ConnectionParams.Password = v
 	}
+	if v := os.Getenv("PLGM_PASSWORD_SOURCE"); v != "" {
+		cfg.ConnectionParams.PasswordSource = v
+	}
 
 	// 2. Default Workload (Explicit Override)
 	if v := os.Getenv("PLGM_DEFAULT_WORKLOAD"); v != "" {
@@ -167,9 +388,15 @@ func applyEnvOverrides(cfg *AppConfig) map[string]bool {
 	if envCollectionsPath := os.Getenv("PLGM_COLLECTIONS_PATH"); envCollectionsPath != "" {
 		cfg.CollectionsPath = envCollectionsPath
 	}
+	if v := os.Getenv("PLGM_COLLECTIONS_SCHEMA_PATH"); v != "" {
+		cfg.CollectionsSchemaPath = v
+	}
 	if envQueriesPath := os.Getenv("PLGM_QUERIES_PATH"); envQueriesPath != "" {
 		cfg.QueriesPath = envQueriesPath
 	}
+	if v := os.Getenv("PLGM_SCENARIO_PATH"); v != "" {
+		cfg.ScenarioPath = v
+	}
 
 	if envDrop := os.Getenv("PLGM_DROP_COLLECTIONS"); envDrop != "" {
 		if b, err := strconv.ParseBool(envDrop); err == nil {
@@ -191,6 +418,17 @@ func applyEnvOverrides(cfg *AppConfig) map[string]bool {
 			cfg.MaxTransactionOps = n
 		}
 	}
+	if v := os.Getenv("PLGM_MAX_TXN_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.MaxTxnRetries = n
+		}
+	}
+	if v := os.Getenv("PLGM_TXN_READ_CONCERN"); v != "" {
+		cfg.TxnReadConcern = v
+	}
+	if v := os.Getenv("PLGM_TXN_WRITE_CONCERN"); v != "" {
+		cfg.TxnWriteConcern = v
+	}
 	if envDocs := os.Getenv("PLGM_DOCUMENTS_COUNT"); envDocs != "" {
 		if n, err := strconv.Atoi(envDocs); err == nil && n >= 0 {
 			cfg.DocumentsCount = n
@@ -204,6 +442,20 @@ func applyEnvOverrides(cfg *AppConfig) map[string]bool {
 	if envDuration := os.Getenv("PLGM_DURATION"); envDuration != "" {
 		cfg.Duration = envDuration
 	}
+	if v := os.Getenv("PLGM_METRICS_LISTEN"); v != "" {
+		cfg.MetricsListen = v
+	}
+	if v := os.Getenv("PLGM_STATSD_ADDR"); v != "" {
+		cfg.StatsdAddr = v
+	}
+	if v := os.Getenv("PLGM_METRICS_PREFIX"); v != "" {
+		cfg.MetricsPrefix = v
+	}
+	if v := os.Getenv("PLGM_RANDOM_SEED"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			cfg.RandomSeed = n
+		}
+	}
 
 	// Percentages - we track these to prioritize them in normalization
 	if p := os.Getenv("PLGM_FIND_PERCENT"); p != "" {
@@ -248,6 +500,65 @@ func applyEnvOverrides(cfg *AppConfig) map[string]bool {
 			overrides["BulkInsertPercent"] = true
 		}
 	}
+	if p := os.Getenv("PLGM_CHANGE_STREAM_PERCENT"); p != "" {
+		if n, err := strconv.Atoi(p); err == nil && n >= 0 {
+			cfg.ChangeStreamPercent = n
+			overrides["ChangeStreamPercent"] = true
+		}
+	}
+	if p := os.Getenv("PLGM_FIND_PAGINATED_PERCENT"); p != "" {
+		if n, err := strconv.Atoi(p); err == nil && n >= 0 {
+			cfg.FindPaginatedPercent = n
+			overrides["FindPaginatedPercent"] = true
+		}
+	}
+	if p := os.Getenv("PLGM_BULK_WRITE_PERCENT"); p != "" {
+		if n, err := strconv.Atoi(p); err == nil && n >= 0 {
+			cfg.BulkWritePercent = n
+			overrides["BulkWritePercent"] = true
+		}
+	}
+	if p := os.Getenv("PLGM_SCENARIO_PERCENT"); p != "" {
+		if n, err := strconv.Atoi(p); err == nil && n >= 0 {
+			cfg.ScenarioPercent = n
+			overrides["ScenarioPercent"] = true
+		}
+	}
+	if p := os.Getenv("PLGM_WATCH_PERCENT"); p != "" {
+		if n, err := strconv.Atoi(p); err == nil && n >= 0 {
+			cfg.WatchPercent = n
+			overrides["WatchPercent"] = true
+		}
+	}
+	if v := os.Getenv("PLGM_WATCH_WORKERS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.WatchWorkers = n
+		}
+	}
+	if v := os.Getenv("PLGM_WATCH_COLLECTION"); v != "" {
+		cfg.WatchCollection = v
+	}
+	if v := os.Getenv("PLGM_WATCH_FULL_DOCUMENT"); v != "" {
+		cfg.WatchFullDocument = v
+	}
+	if v := os.Getenv("PLGM_WATCH_RESUME_TOKEN_FILE"); v != "" {
+		cfg.WatchResumeTokenFile = v
+	}
+	if v := os.Getenv("PLGM_WATCH_START_AT_OPERATION_TIME"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			cfg.WatchStartAtOperationTime = &n
+		}
+	}
+	if v := os.Getenv("PLGM_BULK_WRITE_BATCH_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.BulkWriteBatchSize = n
+		}
+	}
+	if v := os.Getenv("PLGM_BULK_WRITE_ORDERED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.BulkWriteOrdered = b
+		}
+	}
 
 	if v := os.Getenv("PLGM_FIND_BATCH_SIZE"); v != "" {
 		if n, err := strconv.Atoi(v); err == nil && n > 0 {
@@ -294,160 +605,121 @@ func applyEnvOverrides(cfg *AppConfig) map[string]bool {
 			cfg.SeedBatchSize = n
 		}
 	}
-
-	return overrides
-}
-
-func normalizePercentages(cfg *AppConfig, pinned map[string]bool) {
-	// 1. Enforce Transaction flag constraint immediately
-	if !cfg.UseTransactions {
-		cfg.TransactionPercent = 0
-		delete(pinned, "TransactionPercent")
+	if v := os.Getenv("PLGM_CHANGE_STREAM_WINDOW_MS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.ChangeStreamWindowMs = n
+		}
 	}
-
-	// 2. Calculate the total of "pinned" (Environment overridden) stats
-	pinnedTotal := 0
-	if pinned["FindPercent"] {
-		pinnedTotal += cfg.FindPercent
+	if v := os.Getenv("PLGM_CHANGE_STREAM_MAX_EVENTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.ChangeStreamMaxEvents = n
+		}
 	}
-	if pinned["UpdatePercent"] {
-		pinnedTotal += cfg.UpdatePercent
+	if v := os.Getenv("PLGM_CHANGE_STREAM_BATCH_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.ChangeStreamBatchSize = int32(n)
+		}
 	}
-	if pinned["DeletePercent"] {
-		pinnedTotal += cfg.DeletePercent
+	if v := os.Getenv("PLGM_FAIL_ON_ERROR_RATE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 {
+			cfg.FailOnErrorRate = f
+		}
 	}
-	if pinned["InsertPercent"] {
-		pinnedTotal += cfg.InsertPercent
+	if v := os.Getenv("PLGM_DASHBOARD"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.DashboardMode = b
+		}
 	}
-	if pinned["AggregatePercent"] {
-		pinnedTotal += cfg.AggregatePercent
+	if v := os.Getenv("PERCONALOAD_AUTH_MECHANISM"); v != "" {
+		cfg.ConnectionParams.AuthMechanism = v
 	}
-	if pinned["TransactionPercent"] {
-		pinnedTotal += cfg.TransactionPercent
+	if v := os.Getenv("PERCONALOAD_OIDC_TOKEN_FILE"); v != "" {
+		cfg.ConnectionParams.OIDCTokenFile = v
 	}
-	if pinned["BulkInsertPercent"] {
-		pinnedTotal += cfg.BulkInsertPercent
+	if v := os.Getenv("PERCONALOAD_AWS_SESSION_TOKEN"); v != "" {
+		cfg.ConnectionParams.AWSSessionToken = v
 	}
 
-	// 3. Logic:
-	//    If Pinned Total >= 100: Zero out non-pinned, scale pinned if > 100.
-	//    If Pinned Total < 100:  Distribute remainder among unpinned.
-
-	if pinnedTotal >= 100 {
-		// Zero out all non-pinned fields
-		if !pinned["FindPercent"] {
-			cfg.FindPercent = 0
-		}
-		if !pinned["UpdatePercent"] {
-			cfg.UpdatePercent = 0
-		}
-		if !pinned["DeletePercent"] {
-			cfg.DeletePercent = 0
-		}
-		if !pinned["InsertPercent"] {
-			cfg.InsertPercent = 0
-		}
-		if !pinned["AggregatePercent"] {
-			cfg.AggregatePercent = 0
-		}
-		if !pinned["TransactionPercent"] {
-			cfg.TransactionPercent = 0
-		}
-		if !pinned["BulkInsertPercent"] {
-			cfg.BulkInsertPercent = 0
-		}
-
-		// Normalize if pinned values sum > 100
-		if pinnedTotal > 100 {
-			factor := 100.0 / float64(pinnedTotal)
-			if pinned["FindPercent"] {
-				cfg.FindPercent = int(float64(cfg.FindPercent) * factor)
-			}
-			if pinned["UpdatePercent"] {
-				cfg.UpdatePercent = int(float64(cfg.UpdatePercent) * factor)
-			}
-			if pinned["DeletePercent"] {
-				cfg.DeletePercent = int(float64(cfg.DeletePercent) * factor)
-			}
-			if pinned["InsertPercent"] {
-				cfg.InsertPercent = int(float64(cfg.InsertPercent) * factor)
-			}
-			if pinned["AggregatePercent"] {
-				cfg.AggregatePercent = int(float64(cfg.AggregatePercent) * factor)
-			}
-			if pinned["TransactionPercent"] {
-				cfg.TransactionPercent = int(float64(cfg.TransactionPercent) * factor)
-			}
-			if pinned["BulkInsertPercent"] {
-				cfg.BulkInsertPercent = int(float64(cfg.BulkInsertPercent) * factor)
-			}
-		}
-
-	} else {
-		// pinnedTotal < 100. We have space left.
-		remaining := 100 - pinnedTotal
-
-		// Sum of unpinned (default) values
-		unpinnedTotal := 0
-		if !pinned["FindPercent"] {
-			unpinnedTotal += cfg.FindPercent
-		}
-		if !pinned["UpdatePercent"] {
-			unpinnedTotal += cfg.UpdatePercent
-		}
-		if !pinned["DeletePercent"] {
-			unpinnedTotal += cfg.DeletePercent
-		}
-		if !pinned["InsertPercent"] {
-			unpinnedTotal += cfg.InsertPercent
-		}
-		if !pinned["AggregatePercent"] {
-			unpinnedTotal += cfg.AggregatePercent
-		}
-		if !pinned["TransactionPercent"] {
-			unpinnedTotal += cfg.TransactionPercent
-		}
-		if !pinned["BulkInsertPercent"] {
-			unpinnedTotal += cfg.BulkInsertPercent
-		}
-
-		// Scale unpinned values to fill the remaining space
-		if unpinnedTotal > 0 {
-			factor := float64(remaining) / float64(unpinnedTotal)
-
-			if !pinned["FindPercent"] {
-				cfg.FindPercent = int(float64(cfg.FindPercent) * factor)
-			}
-			if !pinned["UpdatePercent"] {
-				cfg.UpdatePercent = int(float64(cfg.UpdatePercent) * factor)
-			}
-			if !pinned["DeletePercent"] {
-				cfg.DeletePercent = int(float64(cfg.DeletePercent) * factor)
-			}
-			if !pinned["InsertPercent"] {
-				cfg.InsertPercent = int(float64(cfg.InsertPercent) * factor)
-			}
-			if !pinned["AggregatePercent"] {
-				cfg.AggregatePercent = int(float64(cfg.AggregatePercent) * factor)
-			}
-			if !pinned["TransactionPercent"] {
-				cfg.TransactionPercent = int(float64(cfg.TransactionPercent) * factor)
-			}
-			if !pinned["BulkInsertPercent"] {
-				cfg.BulkInsertPercent = int(float64(cfg.BulkInsertPercent) * factor)
-			}
-		} else {
-			// Edge case: Pinned values sum to < 100 (e.g. 80%), but all unpinned defaults are 0.
-			// We cannot distribute the remaining 20% proportionally among 0s.
-			// Strategy: Assign the remainder to FindPercent (Selects) to ensure the workload sums to 100%.
-			cfg.FindPercent += remaining
-		}
-	}
-
-	// 4. Final check: Ensure total is exactly 100 (fixing integer rounding errors)
-	finalTotal := cfg.FindPercent + cfg.UpdatePercent + cfg.DeletePercent + cfg.InsertPercent + cfg.AggregatePercent + cfg.TransactionPercent + cfg.BulkInsertPercent
-	if finalTotal != 100 {
-		// Add/Subtract difference to FindPercent (simplest safety net)
-		cfg.FindPercent += (100 - finalTotal)
+	return overrides
+}
+
+// Op kinds for the twelve percentage knobs AppConfig exposes. These match
+// the OpKind values selectOperation/Picker dispatch on in internal/mongo.
+const (
+	OpFind          OpKind = "find"
+	OpUpdate        OpKind = "update"
+	OpDelete        OpKind = "delete"
+	OpInsert        OpKind = "insert"
+	OpInsertMany    OpKind = "insertMany"
+	OpAggregate     OpKind = "aggregate"
+	OpTransaction   OpKind = "transaction"
+	OpChangeStream  OpKind = "changeStream"
+	OpFindPaginated OpKind = "findPaginated"
+	OpBulkWrite     OpKind = "bulkWrite"
+	OpScenario      OpKind = "scenario"
+	OpWatch         OpKind = "watch"
+)
+
+// applyOperationMix builds an OperationMix from cfg's percentage fields
+// (pinned entries came from env overrides and are never rescaled to make
+// room for the rest), normalizes it, and writes the result back into
+// those same fields so existing consumers (e.g. stats.PrintConfiguration)
+// keep reading cfg.FindPercent etc. directly.
+func applyOperationMix(cfg *AppConfig, pinned map[string]bool) {
+	if !cfg.UseTransactions {
+		cfg.TransactionPercent = 0
+		delete(pinned, "TransactionPercent")
+	}
+	if cfg.ScenarioPath == "" {
+		cfg.ScenarioPercent = 0
+		delete(pinned, "ScenarioPercent")
+	}
+	if cfg.WatchWorkers <= 0 {
+		cfg.WatchPercent = 0
+		delete(pinned, "WatchPercent")
+	}
+
+	mix := OperationMix{
+		OpFind:          {Weight: cfg.FindPercent, Pinned: pinned["FindPercent"]},
+		OpUpdate:        {Weight: cfg.UpdatePercent, Pinned: pinned["UpdatePercent"]},
+		OpDelete:        {Weight: cfg.DeletePercent, Pinned: pinned["DeletePercent"]},
+		OpInsert:        {Weight: cfg.InsertPercent, Pinned: pinned["InsertPercent"]},
+		OpInsertMany:    {Weight: cfg.BulkInsertPercent, Pinned: pinned["BulkInsertPercent"]},
+		OpAggregate:     {Weight: cfg.AggregatePercent, Pinned: pinned["AggregatePercent"]},
+		OpTransaction:   {Weight: cfg.TransactionPercent, Pinned: pinned["TransactionPercent"]},
+		OpChangeStream:  {Weight: cfg.ChangeStreamPercent, Pinned: pinned["ChangeStreamPercent"]},
+		OpFindPaginated: {Weight: cfg.FindPaginatedPercent, Pinned: pinned["FindPaginatedPercent"]},
+		OpBulkWrite:     {Weight: cfg.BulkWritePercent, Pinned: pinned["BulkWritePercent"]},
+		OpScenario:      {Weight: cfg.ScenarioPercent, Pinned: pinned["ScenarioPercent"]},
+		OpWatch:         {Weight: cfg.WatchPercent, Pinned: pinned["WatchPercent"]},
+	}
+
+	for _, w := range mix.Normalize() {
+		switch w.Op {
+		case OpFind:
+			cfg.FindPercent = w.Weight
+		case OpUpdate:
+			cfg.UpdatePercent = w.Weight
+		case OpDelete:
+			cfg.DeletePercent = w.Weight
+		case OpInsert:
+			cfg.InsertPercent = w.Weight
+		case OpInsertMany:
+			cfg.BulkInsertPercent = w.Weight
+		case OpAggregate:
+			cfg.AggregatePercent = w.Weight
+		case OpTransaction:
+			cfg.TransactionPercent = w.Weight
+		case OpChangeStream:
+			cfg.ChangeStreamPercent = w.Weight
+		case OpFindPaginated:
+			cfg.FindPaginatedPercent = w.Weight
+		case OpBulkWrite:
+			cfg.BulkWritePercent = w.Weight
+		case OpScenario:
+			cfg.ScenarioPercent = w.Weight
+		case OpWatch:
+			cfg.WatchPercent = w.Weight
+		}
 	}
 }
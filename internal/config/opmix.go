@@ -0,0 +1,158 @@
+package config
+
+import "sort"
+
+// OpKind identifies a workload operation type (e.g. "find", "insertMany").
+// Adding a new operation type means registering it in the OperationMix that
+// applyOperationMix builds (and writing its weight back there), in
+// buildPicker's mix, and mapping it in selectOperation in internal/mongo.
+type OpKind string
+
+// OperationSpec is one operation's share of the mix. Pinned specs (e.g. set
+// explicitly via an env override) are never rescaled to make room for others;
+// unpinned specs absorb whatever percentage pinned specs don't claim.
+type OperationSpec struct {
+	Weight int
+	Pinned bool
+}
+
+// OperationMix is the full set of operations a workload can dispatch,
+// keyed by OpKind. Normalize resolves it into integer percentages that
+// sum to exactly 100.
+type OperationMix map[OpKind]OperationSpec
+
+// WeightedOp is one OpKind's final, normalized share of the mix.
+type WeightedOp struct {
+	Op     OpKind
+	Weight int
+}
+
+// Normalize resolves m into weights that sum to exactly 100:
+//   - If pinned weights already total >= 100, unpinned ops get 0 and
+//     pinned weights are apportioned down to 100 if they total more.
+//   - Otherwise, the 100-pinnedTotal remainder is apportioned across
+//     unpinned ops in proportion to their configured weights (or, if
+//     every unpinned weight is 0, across all unpinned ops equally).
+//
+// Apportionment uses the largest-remainder (Hamilton) method so the
+// last few integer points are distributed fairly instead of being
+// dumped onto a single op.
+//
+// The result is ordered by OpKind for deterministic output.
+func (m OperationMix) Normalize() []WeightedOp {
+	pinnedTotal := 0
+	for _, spec := range m {
+		if spec.Pinned {
+			pinnedTotal += spec.Weight
+		}
+	}
+
+	result := make(map[OpKind]int, len(m))
+
+	if pinnedTotal >= 100 {
+		pinnedWeights := make(map[OpKind]int)
+		for op, spec := range m {
+			if spec.Pinned {
+				pinnedWeights[op] = spec.Weight
+			} else {
+				result[op] = 0
+			}
+		}
+		for op, w := range apportion(pinnedWeights, 100) {
+			result[op] = w
+		}
+	} else {
+		remaining := 100 - pinnedTotal
+		for op, spec := range m {
+			if spec.Pinned {
+				result[op] = spec.Weight
+			}
+		}
+
+		unpinnedWeights := make(map[OpKind]int)
+		unpinnedTotal := 0
+		for op, spec := range m {
+			if !spec.Pinned {
+				unpinnedWeights[op] = spec.Weight
+				unpinnedTotal += spec.Weight
+			}
+		}
+		if unpinnedTotal == 0 {
+			// No signal to scale proportionally from: split the
+			// remainder evenly instead of dumping it on one op.
+			for op := range unpinnedWeights {
+				unpinnedWeights[op] = 1
+			}
+		}
+		for op, w := range apportion(unpinnedWeights, remaining) {
+			result[op] = w
+		}
+	}
+
+	ops := make([]OpKind, 0, len(result))
+	for op := range result {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i] < ops[j] })
+
+	weighted := make([]WeightedOp, 0, len(ops))
+	for _, op := range ops {
+		weighted = append(weighted, WeightedOp{Op: op, Weight: result[op]})
+	}
+	return weighted
+}
+
+// apportion distributes total across weights in proportion to each
+// entry's share, using the largest-remainder (Hamilton) method: each
+// entry first gets floor(share), then the leftover units go to the
+// entries with the largest fractional remainders. The result always
+// sums to exactly total (for total >= 0).
+func apportion(weights map[OpKind]int, total int) map[OpKind]int {
+	result := make(map[OpKind]int, len(weights))
+	if total <= 0 || len(weights) == 0 {
+		for op := range weights {
+			result[op] = 0
+		}
+		return result
+	}
+
+	sumWeights := 0
+	for _, w := range weights {
+		sumWeights += w
+	}
+	if sumWeights <= 0 {
+		for op := range weights {
+			result[op] = 0
+		}
+		return result
+	}
+
+	type share struct {
+		op        OpKind
+		remainder float64
+	}
+	shares := make([]share, 0, len(weights))
+
+	assigned := 0
+	for op, w := range weights {
+		exact := float64(total) * float64(w) / float64(sumWeights)
+		floor := int(exact)
+		result[op] = floor
+		assigned += floor
+		shares = append(shares, share{op: op, remainder: exact - float64(floor)})
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].remainder != shares[j].remainder {
+			return shares[i].remainder > shares[j].remainder
+		}
+		return shares[i].op < shares[j].op
+	})
+
+	leftover := total - assigned
+	for i := 0; i < leftover && i < len(shares); i++ {
+		result[shares[i].op]++
+	}
+
+	return result
+}
@@ -0,0 +1,106 @@
+package config
+
+import "testing"
+
+func TestOperationMixNormalizeSumsTo100(t *testing.T) {
+	cases := []struct {
+		name string
+		mix  OperationMix
+	}{
+		{
+			name: "all unpinned, equal weights",
+			mix: OperationMix{
+				OpFind:   {Weight: 1},
+				OpInsert: {Weight: 1},
+				OpUpdate: {Weight: 1},
+			},
+		},
+		{
+			name: "one pinned under 100, remainder apportioned proportionally",
+			mix: OperationMix{
+				OpFind:   {Weight: 70, Pinned: true},
+				OpInsert: {Weight: 2},
+				OpUpdate: {Weight: 1},
+			},
+		},
+		{
+			name: "pinned weights already exceed 100",
+			mix: OperationMix{
+				OpFind:   {Weight: 80, Pinned: true},
+				OpInsert: {Weight: 60, Pinned: true},
+				OpUpdate: {Weight: 5},
+			},
+		},
+		{
+			name: "unpinned total is zero, remainder split evenly",
+			mix: OperationMix{
+				OpFind:   {Weight: 40, Pinned: true},
+				OpInsert: {Weight: 0},
+				OpUpdate: {Weight: 0},
+				OpDelete: {Weight: 0},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			weighted := tc.mix.Normalize()
+			sum := 0
+			for _, w := range weighted {
+				if w.Weight < 0 {
+					t.Fatalf("negative weight for %s: %d", w.Op, w.Weight)
+				}
+				sum += w.Weight
+			}
+			if sum != 100 {
+				t.Fatalf("weights summed to %d, want 100 (%+v)", sum, weighted)
+			}
+		})
+	}
+}
+
+func TestOperationMixNormalizePinnedExactlyHonored(t *testing.T) {
+	mix := OperationMix{
+		OpFind:   {Weight: 30, Pinned: true},
+		OpInsert: {Weight: 1},
+		OpUpdate: {Weight: 3},
+	}
+	weighted := mix.Normalize()
+	for _, w := range weighted {
+		if w.Op == OpFind && w.Weight != 30 {
+			t.Fatalf("pinned OpFind weight = %d, want 30", w.Weight)
+		}
+	}
+}
+
+func TestApportionLargestRemainder(t *testing.T) {
+	// 100 split 1:1:1 can't divide evenly; Hamilton's method should break
+	// ties deterministically by OpKind so repeated runs agree.
+	weights := map[OpKind]int{OpFind: 1, OpInsert: 1, OpUpdate: 1}
+	got := apportion(weights, 100)
+
+	sum := 0
+	for _, w := range got {
+		sum += w
+	}
+	if sum != 100 {
+		t.Fatalf("apportion summed to %d, want 100 (%+v)", sum, got)
+	}
+
+	got2 := apportion(weights, 100)
+	for op, w := range got {
+		if got2[op] != w {
+			t.Fatalf("apportion not deterministic: %+v vs %+v", got, got2)
+		}
+	}
+}
+
+func TestApportionZeroTotal(t *testing.T) {
+	weights := map[OpKind]int{OpFind: 5, OpInsert: 3}
+	got := apportion(weights, 0)
+	for op, w := range got {
+		if w != 0 {
+			t.Fatalf("apportion(total=0)[%s] = %d, want 0", op, w)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	vaultapi "github.com/hashicorp/vault/api"
+	"golang.org/x/term"
+)
+
+// PasswordProvider resolves a secret for one PasswordSource scheme (e.g.
+// "file", "vault"). source is everything after "<scheme>:", with a leading
+// "//" (if any) stripped.
+type PasswordProvider interface {
+	Resolve(source string) (string, error)
+}
+
+var passwordProviders = map[string]PasswordProvider{
+	"stdin": stdinPasswordProvider{},
+	"file":  filePasswordProvider{},
+	"awssm": awsSecretsManagerProvider{},
+	"gcpsm": gcpSecretManagerProvider{},
+	"vault": vaultPasswordProvider{},
+}
+
+// ResolvePassword returns the effective password for cp. It must be called
+// lazily, right before the value is needed (building the Mongo URI), so a
+// fetched secret doesn't sit in memory/config structs any longer than
+// necessary. An empty or "env" PasswordSource preserves the historical
+// behavior of using cp.Password (itself populated from PLGM_PASSWORD or the
+// config file) directly.
+func ResolvePassword(cp *ConnectionParams) (string, error) {
+	source := cp.PasswordSource
+	if source == "" || source == "env" {
+		return cp.Password, nil
+	}
+
+	scheme := source
+	rest := ""
+	if idx := strings.Index(source, ":"); idx >= 0 {
+		scheme = source[:idx]
+		rest = strings.TrimPrefix(source[idx+1:], "//")
+	}
+
+	provider, ok := passwordProviders[scheme]
+	if !ok {
+		return "", fmt.Errorf("unknown password_source scheme %q", scheme)
+	}
+	return provider.Resolve(rest)
+}
+
+type stdinPasswordProvider struct{}
+
+func (stdinPasswordProvider) Resolve(string) (string, error) {
+	fmt.Fprint(os.Stderr, "Enter Password: ")
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read password from stdin: %w", err)
+	}
+	return string(b), nil
+}
+
+type filePasswordProvider struct{}
+
+func (filePasswordProvider) Resolve(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read password file %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+type awsSecretsManagerProvider struct{}
+
+func (awsSecretsManagerProvider) Resolve(secretName string) (string, error) {
+	ctx := context.Background()
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("load AWS config: %w", err)
+	}
+	client := secretsmanager.NewFromConfig(awsCfg)
+	out, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: &secretName})
+	if err != nil {
+		return "", fmt.Errorf("get AWS secret %s: %w", secretName, err)
+	}
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
+
+type gcpSecretManagerProvider struct{}
+
+func (gcpSecretManagerProvider) Resolve(resourceName string) (string, error) {
+	ctx := context.Background()
+	client, err := secretmanager.NewClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("create GCP Secret Manager client: %w", err)
+	}
+	defer client.Close()
+
+	resp, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: resourceName})
+	if err != nil {
+		return "", fmt.Errorf("access GCP secret %s: %w", resourceName, err)
+	}
+	return string(resp.Payload.Data), nil
+}
+
+type vaultPasswordProvider struct{}
+
+func (vaultPasswordProvider) Resolve(pathAndField string) (string, error) {
+	path := pathAndField
+	field := "value"
+	if idx := strings.Index(pathAndField, "#"); idx >= 0 {
+		path = pathAndField[:idx]
+		field = pathAndField[idx+1:]
+	}
+
+	client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+	if err != nil {
+		return "", fmt.Errorf("create vault client: %w", err)
+	}
+
+	secret, err := client.Logical().Read(path)
+	if err != nil {
+		return "", fmt.Errorf("read vault secret %s: %w", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("vault secret %s not found", path)
+	}
+	val, ok := secret.Data[field]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no field %q", path, field)
+	}
+	s, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %s field %q is not a string", path, field)
+	}
+	return s, nil
+}
@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/Percona-Lab/percona-load-generator-mongodb/resources"
+	"gopkg.in/yaml.v3"
 )
 
 type QueryDefinition struct {
@@ -20,6 +20,24 @@ type QueryDefinition struct {
 	Limit      int64                  `json:"limit,omitempty" yaml:"limit,omitempty"`
 	Update     map[string]interface{} `json:"update,omitempty" yaml:"update,omitempty"`
 	Upsert     bool                   `json:"upsert,omitempty" yaml:"upsert,omitempty"`
+
+	// FullDocument/FullDocumentBeforeChange configure a "changeStream"
+	// operation's pre/post images: one of "default", "updateLookup",
+	// "whenAvailable", "required" (see options.ChangeStream in the Mongo
+	// driver). Pipeline, for this operation, is the change stream's own
+	// aggregation pipeline (e.g. a $match on operationType).
+	FullDocument             string `json:"fullDocument,omitempty" yaml:"fullDocument,omitempty"`
+	FullDocumentBeforeChange string `json:"fullDocumentBeforeChange,omitempty" yaml:"fullDocumentBeforeChange,omitempty"`
+
+	// Sort/PageSize/MaxPages configure a "findPaginated" operation's
+	// keyset pagination: Sort is a single-key sort whose key is the field
+	// paged on (with several keys, which one is paged on is not defined), PageSize
+	// caps each page's Find().SetLimit, and MaxPages bounds how many
+	// pages a single worker dispatch scrolls through before returning
+	// (see internal/cursortoken).
+	Sort     map[string]interface{} `json:"sort,omitempty" yaml:"sort,omitempty"`
+	PageSize int64                  `json:"pageSize,omitempty" yaml:"pageSize,omitempty"`
+	MaxPages int                    `json:"maxPages,omitempty" yaml:"maxPages,omitempty"`
 }
 
 type QueriesFile struct {
@@ -58,11 +76,11 @@ func LoadQueries(path string, loadDefault bool) (*QueriesFile, error) {
 		}
 
 		for _, entry := range entries {
-			if entry.IsDir() || !strings.HasSuffix(strings.ToLower(entry.Name()), ".json") {
+			if entry.IsDir() || !isDataFile(entry.Name()) {
 				continue
 			}
 
-			isDefault := strings.EqualFold(entry.Name(), "default.json")
+			isDefault := isDefaultFile(entry.Name())
 
 			if loadDefault {
 				if !isDefault {
@@ -99,6 +117,11 @@ func loadEmbeddedQuery(embedPath string) (*QueriesFile, error) {
 		return nil, fmt.Errorf("failed to read embedded file %s: %w", embedPath, err)
 	}
 
+	b, err = renderTemplate(embedPath, b)
+	if err != nil {
+		return nil, err
+	}
+
 	var defs []QueryDefinition
 	if err := json.Unmarshal(b, &defs); err != nil {
 		return nil, fmt.Errorf("invalid JSON format for embedded queries: %w", err)
@@ -112,9 +135,20 @@ func loadQueriesFromFile(path string) (*QueriesFile, error) {
 		return nil, fmt.Errorf("read queries file: %w", err)
 	}
 
+	b, err = renderTemplate(path, b)
+	if err != nil {
+		return nil, err
+	}
+
 	var defs []QueryDefinition
-	if err := json.Unmarshal(b, &defs); err != nil {
-		return nil, fmt.Errorf("invalid JSON format for queries: %w", err)
+	if isYAMLFile(path) {
+		if err := yaml.Unmarshal(b, &defs); err != nil {
+			return nil, fmt.Errorf("invalid YAML format for queries: %w", err)
+		}
+	} else {
+		if err := json.Unmarshal(b, &defs); err != nil {
+			return nil, fmt.Errorf("invalid JSON format for queries: %w", err)
+		}
 	}
 
 	return &QueriesFile{Queries: defs}, nil
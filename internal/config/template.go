@@ -0,0 +1,116 @@
+package config
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	mathrand "math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// isDataFile reports whether name is a query/collection definition file
+// this loader knows how to parse (JSON or YAML).
+func isDataFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// isDefaultFile reports whether name is the built-in "default" collection
+// or query file, regardless of its extension.
+func isDefaultFile(name string) bool {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	return strings.EqualFold(base, "default")
+}
+
+// isYAMLFile reports whether name should be parsed as YAML rather than JSON.
+func isYAMLFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// templateFuncs are available inside query/collection files so users can
+// parameterize filters and pipelines without writing Go, e.g. a rolling
+// time-window aggregation: `$gte: "{{ now | addDuration "-1h" }}"`.
+//
+// Functions return strings (rather than time.Time/etc.) so the rendered
+// output drops straight into the surrounding JSON/YAML text; the file
+// author is responsible for quoting the placeholder where a string is
+// expected. Placeholders like "<int>" used by getTypeFromPlaceholder are
+// plain text to text/template (it only acts on "{{ ... }}") so they pass
+// through untouched.
+var templateFuncs = template.FuncMap{
+	"randInt": func(min, max int) int {
+		if max <= min {
+			return min
+		}
+		return min + mathrand.Intn(max-min+1)
+	},
+	"now": func() string {
+		return time.Now().UTC().Format(time.RFC3339Nano)
+	},
+	"env": os.Getenv,
+	"uuid": func() string {
+		return newUUID()
+	},
+	"addDuration": func(d string, ts string) string {
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return ts
+		}
+		dur, err := time.ParseDuration(d)
+		if err != nil {
+			return ts
+		}
+		return t.Add(dur).UTC().Format(time.RFC3339Nano)
+	},
+}
+
+// renderTemplate expands Go-template placeholders in a query/collection
+// file before it is unmarshalled. Files with no "{{" are returned
+// unchanged (cheap and keeps error messages obvious for non-templated
+// files).
+func renderTemplate(name string, b []byte) ([]byte, error) {
+	if !bytes.Contains(b, []byte("{{")) {
+		return b, nil
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("parse template in %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return nil, fmt.Errorf("render template in %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID string.
+func newUUID() string {
+	b := make([]byte, 16)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(256))
+		if err != nil {
+			n = big.NewInt(int64(mathrand.Intn(256)))
+		}
+		b[i] = byte(n.Int64())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,49 @@
+// Package cursortoken implements opaque keyset-pagination cursors for the
+// findPaginated workload: callers never inspect a Token's fields
+// directly, only Encode one to hand back as a page's "next cursor" and
+// Decode one they received to resume from.
+package cursortoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Token is the keyset state needed to fetch the next page without
+// re-scanning from the start: the last page's sort value and _id (for
+// tie-breaking equal sort values), the scroll direction, and the page
+// size the caller asked for.
+type Token struct {
+	LastSortValue interface{} `json:"lastSortValue,omitempty"`
+	LastID        interface{} `json:"lastId,omitempty"`
+	Direction     string      `json:"direction,omitempty"` // "next" (default) or "prev"
+	PageSize      int64       `json:"pageSize,omitempty"`
+}
+
+// Encode serializes t into an opaque base64-JSON string suitable for
+// handing back to a caller as a pagination cursor.
+func Encode(t Token) (string, error) {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor token: %w", err)
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// Decode parses a token previously returned by Encode. An empty string
+// decodes to the zero Token (the first page).
+func Decode(s string) (Token, error) {
+	var t Token
+	if s == "" {
+		return t, nil
+	}
+	b, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return t, fmt.Errorf("decode cursor token: %w", err)
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return t, fmt.Errorf("decode cursor token: %w", err)
+	}
+	return t, nil
+}
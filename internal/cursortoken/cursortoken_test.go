@@ -0,0 +1,41 @@
+package cursortoken
+
+import "testing"
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	want := Token{
+		LastSortValue: "2026-07-25",
+		LastID:        "abc123",
+		Direction:     "next",
+		PageSize:      50,
+	}
+
+	s, err := Encode(want)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	got, err := Decode(s)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got != want {
+		t.Fatalf("Decode(Encode(t)) = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeEmptyStringIsFirstPage(t *testing.T) {
+	got, err := Decode("")
+	if err != nil {
+		t.Fatalf("Decode(\"\") error = %v", err)
+	}
+	if got != (Token{}) {
+		t.Fatalf("Decode(\"\") = %+v, want zero Token", got)
+	}
+}
+
+func TestDecodeInvalidTokenErrors(t *testing.T) {
+	if _, err := Decode("not-valid-base64!!"); err == nil {
+		t.Fatalf("Decode() on garbage input returned nil error")
+	}
+}
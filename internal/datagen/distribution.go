@@ -0,0 +1,121 @@
+package datagen
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+)
+
+// zipfCache holds one *rand.Zipf per (rng, field path, parameters) so a field
+// sampled repeatedly off the same Faker's rng - e.g. every element of an
+// array field, or a document re-rolled many times against a cached Faker -
+// doesn't recompute the constants rand.NewZipf precomputes on every call.
+// gofakeit.Faker is a third-party type with nowhere to hang a cache field, so
+// this is keyed by the rng pointer instead, which gives it the same
+// lifetime: each Faker owns exactly one *rand.Rand, and a cached Zipf is
+// only ever reused by calls sharing that same rng, so it never reads
+// randomness out of order for a different worker/seed's stream.
+var zipfCache sync.Map
+
+type zipfCacheKey struct {
+	rng  *rand.Rand
+	path string
+	min  int
+	max  int
+	s    float64
+	v    float64
+}
+
+// SampleInt draws an int in [min, max] according to dist. A nil dist, or one
+// with an empty/"uniform" Kind, reproduces the old rng.Intn(max-min+1)+min
+// behavior so fields without a distribution block are unaffected. rng is the
+// caller's (worker- or document-scoped) RNG, so sampling stays reproducible
+// under a fixed config.AppConfig.RandomSeed. path identifies the field being
+// sampled (see RandomValueWithFaker) and is only used to key per-field
+// distribution state such as the zipf cache below.
+func SampleInt(dist *config.Distribution, rng *rand.Rand, min, max int, path string) int {
+	if max < min {
+		min, max = max, min
+	}
+	span := max - min
+
+	if dist == nil || dist.Kind == "" || dist.Kind == "uniform" {
+		return min + rng.Intn(span+1)
+	}
+
+	switch dist.Kind {
+	case "zipf":
+		s := dist.S
+		if s <= 1 {
+			s = 1.1
+		}
+		v := dist.V
+		if v < 1 {
+			v = 1
+		}
+		key := zipfCacheKey{rng: rng, path: path, min: min, max: max, s: s, v: v}
+		var z *rand.Zipf
+		if cached, ok := zipfCache.Load(key); ok {
+			z = cached.(*rand.Zipf)
+		} else {
+			z = rand.NewZipf(rng, s, v, uint64(span))
+			zipfCache.Store(key, z)
+		}
+		return min + int(z.Uint64())
+
+	case "pareto":
+		alpha := dist.S
+		if alpha <= 0 {
+			alpha = 1.5
+		}
+		// Classic Pareto CDF inverse (x_m=1), normalized against the p=0.99
+		// tail so the heavy tail stays inside [min, max] instead of being
+		// clamped away most of the time.
+		p := rng.Float64()
+		raw := math.Pow(1-p, -1/alpha) - 1
+		tailCap := math.Pow(1-0.99, -1/alpha) - 1
+		frac := raw / tailCap
+		if frac > 1 {
+			frac = 1
+		}
+		return min + int(frac*float64(span))
+
+	case "hotspot":
+		hotFraction := dist.HotFraction
+		if hotFraction <= 0 {
+			hotFraction = 0.1
+		}
+		hotWeight := dist.HotWeight
+		if hotWeight <= 0 {
+			hotWeight = 0.8
+		}
+		if rng.Float64() < hotWeight {
+			hotSpan := int(hotFraction * float64(span))
+			return min + rng.Intn(hotSpan+1)
+		}
+		return min + rng.Intn(span+1)
+
+	case "normal":
+		mean := dist.Mean
+		if mean == 0 {
+			mean = float64(min+max) / 2
+		}
+		stddev := dist.StdDev
+		if stddev <= 0 {
+			stddev = float64(span) / 6
+		}
+		val := rng.NormFloat64()*stddev + mean
+		if val < float64(min) {
+			val = float64(min)
+		}
+		if val > float64(max) {
+			val = float64(max)
+		}
+		return int(math.Round(val))
+
+	default:
+		return min + rng.Intn(span+1)
+	}
+}
@@ -0,0 +1,103 @@
+// Package jsonschema derives config.CollectionField definitions from a
+// MongoDB $jsonSchema validator, so a collection that already has a schema
+// doesn't need its fields hand-duplicated as YAML/JSON.
+package jsonschema
+
+import (
+	"strings"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+)
+
+// Schema is the BSON-flavored subset of JSON Schema that $jsonSchema
+// validators use.
+type Schema struct {
+	BsonType   string            `json:"bsonType,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Properties map[string]Schema `json:"properties,omitempty"`
+	Required   []string          `json:"required,omitempty"`
+	Items      *Schema           `json:"items,omitempty"`
+	Enum       []string          `json:"enum,omitempty"`
+	Minimum    *int              `json:"minimum,omitempty"`
+	Maximum    *int              `json:"maximum,omitempty"`
+	MinLength  int               `json:"minLength,omitempty"`
+	MaxLength  int               `json:"maxLength,omitempty"`
+	Pattern    string            `json:"pattern,omitempty"`
+}
+
+// CollectionFile is the on-disk wrapper around a $jsonSchema validator: the
+// validator itself doesn't carry a database/collection name, so the file
+// provides them alongside it.
+type CollectionFile struct {
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	JSONSchema Schema `json:"jsonSchema"`
+}
+
+// ToCollectionDefinition converts a parsed schema file into the same
+// CollectionDefinition shape hand-written collection YAML/JSON produces.
+func ToCollectionDefinition(cf CollectionFile) config.CollectionDefinition {
+	return config.CollectionDefinition{
+		DatabaseName: cf.Database,
+		Name:         cf.Collection,
+		Fields:       fieldsFromProperties(cf.JSONSchema.Properties),
+	}
+}
+
+func fieldsFromProperties(properties map[string]Schema) map[string]config.CollectionField {
+	fields := make(map[string]config.CollectionField, len(properties))
+	for name, prop := range properties {
+		fields[name] = fieldFromSchema(prop)
+	}
+	return fields
+}
+
+func fieldFromSchema(s Schema) config.CollectionField {
+	field := config.CollectionField{
+		Type:      mapType(s),
+		Enum:      s.Enum,
+		Pattern:   s.Pattern,
+		MinLength: s.MinLength,
+		MaxLength: s.MaxLength,
+	}
+	if s.Minimum != nil {
+		field.Min = s.Minimum
+	}
+	if s.Maximum != nil {
+		field.Max = s.Maximum
+	}
+
+	switch strings.ToLower(field.Type) {
+	case "object", "document":
+		field.Fields = fieldsFromProperties(s.Properties)
+	case "array":
+		if s.Items != nil {
+			item := fieldFromSchema(*s.Items)
+			field.Items = &item
+		}
+	}
+
+	return field
+}
+
+// mapType normalizes $jsonSchema's "bsonType" (preferred) or plain JSON
+// Schema "type" into the type names config.CollectionField/RandomValueWithFaker
+// already understand.
+func mapType(s Schema) string {
+	t := s.BsonType
+	if t == "" {
+		t = s.Type
+	}
+	switch strings.ToLower(t) {
+	case "integer":
+		return "int"
+	case "number":
+		return "double"
+	case "boolean":
+		return "bool"
+	case "objectid":
+		return "objectid"
+	default:
+		return strings.ToLower(t)
+	}
+}
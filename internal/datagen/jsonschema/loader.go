@@ -0,0 +1,61 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+)
+
+// LoadCollections reads one or more $jsonSchema collection files from path
+// (a single file, or a directory of *.json files) and converts each into a
+// config.CollectionDefinition, mirroring config.LoadCollections' disk
+// layout so the two loaders are interchangeable.
+func LoadCollections(path string) (*config.CollectionsFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat collections schema path %s: %w", path, err)
+	}
+
+	var files []string
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("read collections schema dir: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+				continue
+			}
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	} else {
+		files = append(files, path)
+	}
+
+	var result config.CollectionsFile
+	for _, file := range files {
+		cf, err := loadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("load collection schema %s: %w", file, err)
+		}
+		result.Collections = append(result.Collections, ToCollectionDefinition(*cf))
+	}
+
+	return &result, nil
+}
+
+func loadFile(path string) (*CollectionFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cf CollectionFile
+	if err := json.Unmarshal(b, &cf); err != nil {
+		return nil, err
+	}
+	return &cf, nil
+}
@@ -26,11 +26,34 @@ func toCamelCase(s string) string {
 }
 
 // RandomValueWithFaker uses an existing Faker instance to generate values.
-// This is much faster than creating a new Faker for every field.
-func RandomValueWithFaker(def config.CollectionField, faker *gofakeit.Faker) interface{} {
+// This is much faster than creating a new Faker for every field. path is the
+// field's dotted/indexed position in the document (e.g. "address.zip",
+// "items[]"); it's threaded down to SampleInt so a distribution that caches
+// per-field state (e.g. "zipf") can key its cache.
+func RandomValueWithFaker(def config.CollectionField, faker *gofakeit.Faker, path string) interface{} {
 	// Use the RNG inside the faker instance for raw math operations
 	rng := faker.Rand
 
+	// 0. Cross-field reference: sample a key already seeded into another
+	// collection/field instead of generating an independent value.
+	if strings.EqualFold(def.Provider, "ref") {
+		return randomRefValue(def, faker, path)
+	}
+
+	// 0b. Schema-derived constraints ($jsonSchema "pattern"/"enum", or the
+	// same written by hand in YAML) take priority over the type default.
+	// Skipped for an encrypted field: a regex-matched value only makes
+	// sense if the field can be queried with $regex, which CSFLE/Queryable
+	// Encryption never support (deterministic allows $eq only; random and
+	// QE range/equality don't even support that on the ciphertext), so the
+	// type-based default below is used instead.
+	if def.Pattern != "" && def.Encrypt == nil {
+		return faker.Regex(def.Pattern)
+	}
+	if len(def.Enum) > 0 {
+		return faker.RandomString(def.Enum)
+	}
+
 	// 1. Dynamic Provider Lookup (Reflection)
 	if def.Provider != "" {
 		methodName := toCamelCase(def.Provider)
@@ -88,7 +111,7 @@ func RandomValueWithFaker(def config.CollectionField, faker *gofakeit.Faker) int
 		if def.Max != nil {
 			max = *def.Max
 		}
-		return int32(rng.Intn(max-min+1) + min)
+		return int32(SampleInt(def.Distribution, rng, min, max, path))
 
 	case "long", "int64":
 		return rng.Int63()
@@ -135,7 +158,7 @@ func RandomValueWithFaker(def config.CollectionField, faker *gofakeit.Faker) int
 		if len(def.Fields) > 0 {
 			doc := make(bson.D, 0, len(def.Fields))
 			for key, fieldDef := range def.Fields {
-				val := RandomValueWithFaker(fieldDef, faker)
+				val := RandomValueWithFaker(fieldDef, faker, path+"."+key)
 				doc = append(doc, bson.E{Key: key, Value: val})
 			}
 			return doc
@@ -151,7 +174,7 @@ func RandomValueWithFaker(def config.CollectionField, faker *gofakeit.Faker) int
 
 		if def.Items != nil {
 			for i := 0; i < size; i++ {
-				arr[i] = RandomValueWithFaker(*def.Items, faker)
+				arr[i] = RandomValueWithFaker(*def.Items, faker, path+"[]")
 			}
 		} else {
 			for i := 0; i < size; i++ {
@@ -165,8 +188,39 @@ func RandomValueWithFaker(def config.CollectionField, faker *gofakeit.Faker) int
 	}
 }
 
+// randomRefValue resolves a "ref" provider field against the process-wide
+// RefRegistry. If RefCollection/RefField hasn't produced any keys yet (e.g.
+// it seeds after this collection), a placeholder of the field's own declared
+// Type is generated and recorded in its place, so a later pass over the
+// referencing collection's data - or a later ref lookup - can still observe it.
+// RefCardinality == "many" repeats this ArraySize times (default 3) into a
+// slice instead of returning a single value.
+func randomRefValue(def config.CollectionField, faker *gofakeit.Faker, path string) interface{} {
+	if strings.EqualFold(def.RefCardinality, "many") {
+		size := def.ArraySize
+		if size <= 0 {
+			size = 3
+		}
+		vals := make([]interface{}, size)
+		for i := range vals {
+			vals[i] = randomSingleRefValue(def, faker, path)
+		}
+		return vals
+	}
+	return randomSingleRefValue(def, faker, path)
+}
+
+func randomSingleRefValue(def config.CollectionField, faker *gofakeit.Faker, path string) interface{} {
+	if val, ok := SampleRef(def.RefCollection, def.RefField, def.RefStrategy, faker.Rand); ok {
+		return val
+	}
+	placeholder := RandomValueWithFaker(config.CollectionField{Type: def.Type}, faker, path)
+	RecordRef(def.RefCollection, def.RefField, placeholder)
+	return placeholder
+}
+
 // RandomValue convenience wrapper (slower, creates new faker)
 func RandomValue(def config.CollectionField) interface{} {
 	faker := gofakeit.New(time.Now().UnixNano())
-	return RandomValueWithFaker(def, faker)
+	return RandomValueWithFaker(def, faker, "")
 }
@@ -0,0 +1,142 @@
+package datagen
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// refKey identifies the ring buffer holding previously generated values for
+// one (collection, field) pair, e.g. ("users", "_id").
+type refKey struct {
+	collection string
+	field      string
+}
+
+// ringBuffer keeps the most recent N generated values for a single field so
+// a "ref" provider elsewhere can sample a key that actually exists.
+type ringBuffer struct {
+	mu     sync.Mutex
+	values []interface{}
+	count  int
+	next   int
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &ringBuffer{values: make([]interface{}, capacity)}
+}
+
+func (b *ringBuffer) add(v interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.values[b.next] = v
+	b.next = (b.next + 1) % len(b.values)
+	if b.count < len(b.values) {
+		b.count++
+	}
+}
+
+// sample draws a previously recorded value. strategy "recent" biases toward
+// the most recently added values (a small trailing window); "zipf" biases
+// toward recent values too, but with a long tail into older ones; anything
+// else (including "") samples uniformly across everything still buffered.
+func (b *ringBuffer) sample(strategy string, rng *rand.Rand) (interface{}, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.count == 0 {
+		return nil, false
+	}
+
+	var age int // 0 = most recently added
+	switch strategy {
+	case "recent":
+		window := b.count/10 + 1
+		age = rng.Intn(window)
+	case "zipf":
+		z := rand.NewZipf(rng, 1.5, 1, uint64(b.count-1))
+		age = int(z.Uint64())
+	default:
+		age = rng.Intn(b.count)
+	}
+
+	pos := (b.next - 1 - age + len(b.values)) % len(b.values)
+	return b.values[pos], true
+}
+
+// RefRegistry is a process-wide table of ring buffers keyed by
+// (collection, field), populated during document generation and consumed by
+// the "ref" provider and by query generation for filter values.
+type RefRegistry struct {
+	mu       sync.RWMutex
+	buffers  map[refKey]*ringBuffer
+	capacity int
+}
+
+// NewRefRegistry builds an empty registry. capacity bounds each
+// (collection,field) ring buffer; callers typically pass
+// config.AppConfig.InsertCacheSize.
+func NewRefRegistry(capacity int) *RefRegistry {
+	return &RefRegistry{buffers: make(map[refKey]*ringBuffer), capacity: capacity}
+}
+
+func (r *RefRegistry) bufferFor(collection, field string) *ringBuffer {
+	key := refKey{collection, field}
+
+	r.mu.RLock()
+	b := r.buffers[key]
+	r.mu.RUnlock()
+	if b != nil {
+		return b
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if b = r.buffers[key]; b == nil {
+		b = newRingBuffer(r.capacity)
+		r.buffers[key] = b
+	}
+	return b
+}
+
+// Record stores a freshly generated value for (collection, field).
+func (r *RefRegistry) Record(collection, field string, value interface{}) {
+	r.bufferFor(collection, field).add(value)
+}
+
+// Sample draws a previously recorded value for (collection, field), ok is
+// false if nothing has been recorded for it yet.
+func (r *RefRegistry) Sample(collection, field, strategy string, rng *rand.Rand) (interface{}, bool) {
+	return r.bufferFor(collection, field).sample(strategy, rng)
+}
+
+var defaultRefRegistry = NewRefRegistry(1000)
+
+// InitRefRegistry (re)initializes the process-wide ref registry with the
+// given per-(collection,field) ring-buffer capacity. Call once at startup,
+// before seeding/workload generation begins.
+func InitRefRegistry(capacity int) {
+	defaultRefRegistry = NewRefRegistry(capacity)
+}
+
+// RecordRef records a generated value for (collection, field) in the
+// process-wide registry.
+func RecordRef(collection, field string, value interface{}) {
+	defaultRefRegistry.Record(collection, field, value)
+}
+
+// SampleRef draws a previously recorded value for (collection, field) from
+// the process-wide registry.
+func SampleRef(collection, field, strategy string, rng *rand.Rand) (interface{}, bool) {
+	return defaultRefRegistry.Sample(collection, field, strategy, rng)
+}
+
+// RecordDocumentFields records every top-level field of a generated document
+// under (collection, fieldName), so "ref" provider fields on other
+// collections can later sample a key that actually exists.
+func RecordDocumentFields(collection string, doc map[string]interface{}) {
+	for field, val := range doc {
+		RecordRef(collection, field, val)
+	}
+}
@@ -0,0 +1,59 @@
+package datagen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRingBufferWrapsAtCapacity(t *testing.T) {
+	b := newRingBuffer(3)
+	for i := 0; i < 5; i++ {
+		b.add(i)
+	}
+	if b.count != 3 {
+		t.Fatalf("count = %d, want 3 (capped at capacity)", b.count)
+	}
+
+	seen := map[interface{}]bool{}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		v, ok := b.sample("", rng)
+		if !ok {
+			t.Fatalf("sample() ok = false on non-empty buffer")
+		}
+		seen[v] = true
+	}
+	for _, v := range []int{0, 1} {
+		if seen[v] {
+			t.Fatalf("sample() returned overwritten value %d, want only {2,3,4}", v)
+		}
+	}
+	for _, v := range []int{2, 3, 4} {
+		if !seen[v] {
+			t.Fatalf("sample() never returned %d across 50 draws from a 3-slot buffer", v)
+		}
+	}
+}
+
+func TestRingBufferEmptySampleIsNotOK(t *testing.T) {
+	b := newRingBuffer(10)
+	if _, ok := b.sample("", rand.New(rand.NewSource(1))); ok {
+		t.Fatalf("sample() on empty buffer returned ok = true")
+	}
+}
+
+func TestRefRegistryRecordAndSampleByCollectionAndField(t *testing.T) {
+	r := NewRefRegistry(10)
+	r.Record("users", "_id", "u1")
+	r.Record("orders", "_id", "o1")
+
+	rng := rand.New(rand.NewSource(1))
+	v, ok := r.Sample("users", "_id", "", rng)
+	if !ok || v != "u1" {
+		t.Fatalf("Sample(users,_id) = (%v, %v), want (u1, true)", v, ok)
+	}
+
+	if _, ok := r.Sample("users", "email", "", rng); ok {
+		t.Fatalf("Sample on a never-recorded field returned ok = true")
+	}
+}
@@ -0,0 +1,40 @@
+package datagen
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// NewFaker builds a Faker seeded deterministically: the same seed always
+// produces the same sequence of generated values, which is what makes a
+// workload run reproducible across seeds, inserts and query filters alike.
+func NewFaker(seed int64) *gofakeit.Faker {
+	return gofakeit.New(seed)
+}
+
+// SeedOrTime returns seed when it is non-zero (the user opted into a fixed
+// seed), otherwise a time-based seed so behavior without RandomSeed set is
+// unchanged from before.
+func SeedOrTime(seed int64) int64 {
+	if seed != 0 {
+		return seed
+	}
+	return time.Now().UnixNano()
+}
+
+// DeriveWorkerSeed derives a reproducible per-worker seed from a base seed
+// so concurrent workers don't all generate the same document/value stream.
+func DeriveWorkerSeed(base int64, workerID int) int64 {
+	return base ^ int64(workerID)
+}
+
+// DeriveNamedSeed derives a reproducible seed for a named entity (e.g. a
+// collection) from a base seed, for generators that aren't keyed by a
+// worker id (such as the background insert-cache producer).
+func DeriveNamedSeed(base int64, name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return base ^ int64(h.Sum64())
+}
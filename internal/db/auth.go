@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// buildCredential turns cfg's AuthMechanism/AuthMechanismProperties (and, for
+// MONGODB-OIDC, its callback settings) into an options.Credential for
+// clientOptions.SetAuth. Returns nil when AuthMechanism is unset, leaving
+// BuildMongoURI's username/password-in-URI handling as the sole auth path,
+// same as before this field existed.
+func buildCredential(cfg *config.AppConfig) (*options.Credential, error) {
+	cp := &cfg.ConnectionParams
+	if cp.AuthMechanism == "" {
+		return nil, nil
+	}
+
+	cred := options.Credential{
+		AuthMechanism: cp.AuthMechanism,
+		Username:      cp.Username,
+		AuthSource:    cp.AuthSource,
+	}
+
+	if len(cp.AuthMechanismProperties) > 0 {
+		cred.AuthMechanismProperties = make(map[string]string, len(cp.AuthMechanismProperties))
+		for k, v := range cp.AuthMechanismProperties {
+			cred.AuthMechanismProperties[k] = v
+		}
+	}
+	if cp.AWSSessionToken != "" {
+		if cred.AuthMechanismProperties == nil {
+			cred.AuthMechanismProperties = make(map[string]string, 1)
+		}
+		cred.AuthMechanismProperties["AWS_SESSION_TOKEN"] = cp.AWSSessionToken
+	}
+
+	if cp.AuthMechanism == "MONGODB-OIDC" {
+		if cp.OIDCTokenFile == "" && cp.OIDCHumanCommand == "" {
+			return nil, fmt.Errorf("MONGODB-OIDC requires oidc_token_file (machine flow) and/or oidc_human_command (human flow)")
+		}
+		if cp.OIDCTokenFile != "" {
+			cred.OIDCMachineCallback = tokenFileOIDCCallback(cp.OIDCTokenFile)
+		}
+		if cp.OIDCHumanCommand != "" {
+			cred.OIDCHumanCallback = commandOIDCCallback(cp.OIDCHumanCommand)
+		}
+	}
+
+	return &cred, nil
+}
+
+// tokenFileOIDCCallback implements the MONGODB-OIDC machine-workload flow:
+// the token is read fresh from tokenFile on every invocation instead of
+// being cached here. The driver itself caches the returned access token and
+// invalidates that cache on a ReauthenticationRequired error, at which point
+// it calls back in here again - so never caching on our side is what makes
+// that re-auth actually pick up a rotated token instead of replaying a
+// stale one.
+func tokenFileOIDCCallback(tokenFile string) options.OIDCCallback {
+	return func(_ context.Context, _ *options.OIDCArgs) (*options.OIDCCredential, error) {
+		b, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("read OIDC token file %s: %w", tokenFile, err)
+		}
+		return &options.OIDCCredential{AccessToken: strings.TrimSpace(string(b))}, nil
+	}
+}
+
+// commandOIDCCallback implements the MONGODB-OIDC human-workload flow: an
+// external IdP login helper (e.g. a browser-based device-code flow driven
+// by the IdP's own CLI) is invoked on every driver callback, and its
+// trimmed stdout is used as the access token. Like tokenFileOIDCCallback,
+// this never caches, so a driver-triggered re-auth re-runs the command
+// instead of reusing a token the IdP may have already revoked.
+func commandOIDCCallback(command string) options.OIDCCallback {
+	return func(ctx context.Context, _ *options.OIDCArgs) (*options.OIDCCredential, error) {
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty oidc_human_command")
+		}
+		cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("run OIDC human command %q: %w", command, err)
+		}
+		return &options.OIDCCredential{AccessToken: strings.TrimSpace(string(out))}, nil
+	}
+}
@@ -0,0 +1,238 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// csfleAlgorithms maps EncryptSpec.Algorithm to the driver's AEAD
+// identifier - the two CSFLE (non-Queryable-Encryption) algorithms.
+var csfleAlgorithms = map[string]string{
+	"deterministic": "AEAD_AES_256_CBC_HMAC_SHA_512-Deterministic",
+	"random":        "AEAD_AES_256_CBC_HMAC_SHA_512-Random",
+}
+
+// bsonTypeFor maps a CollectionField.Type to the $jsonSchema/encryptedFields
+// bsonType an encrypted field must declare, so a deterministic field (which
+// must always encrypt the same plaintext to the same ciphertext) never
+// drifts to a different wire type across runs.
+func bsonTypeFor(fieldType string) string {
+	switch fieldType {
+	case "int", "integer", "int32":
+		return "int"
+	case "long", "int64":
+		return "long"
+	case "double", "float":
+		return "double"
+	case "decimal", "decimal128":
+		return "decimal"
+	case "bool", "boolean":
+		return "bool"
+	case "date", "datetime":
+		return "date"
+	case "objectid":
+		return "objectId"
+	default:
+		return "string"
+	}
+}
+
+// setupCSFLE builds the AutoEncryption options cfg.CSFLE describes and
+// applies them to clientOptions. It connects a short-lived, unencrypted
+// client against the same URI to create/look up data keys (ClientEncryption
+// must never itself go through auto-encryption), then tears it down -
+// Connect's real client is the only long-lived connection this opens.
+func setupCSFLE(ctx context.Context, cfg *config.AppConfig, finalURI string, clientOptions *options.ClientOptions, collections []config.CollectionDefinition) error {
+	csfle := cfg.CSFLE
+	if csfle.KeyVaultNamespace == "" {
+		return fmt.Errorf("csfle.enabled requires key_vault_namespace")
+	}
+
+	keyVaultClient, err := mongo.Connect(options.Client().ApplyURI(finalURI))
+	if err != nil {
+		return fmt.Errorf("connect CSFLE key vault client: %w", err)
+	}
+	defer func() { _ = keyVaultClient.Disconnect(ctx) }()
+
+	keyIDs, err := EnsureDataKeys(ctx, keyVaultClient, csfle, collections)
+	if err != nil {
+		return fmt.Errorf("ensure CSFLE data keys: %w", err)
+	}
+
+	aeOpts := options.AutoEncryption().
+		SetKeyVaultNamespace(csfle.KeyVaultNamespace).
+		SetKmsProviders(csfle.KMSProviders)
+
+	if csfle.QueryableEncryption {
+		fieldsMap := csfle.EncryptedFieldsMap
+		if fieldsMap == nil {
+			if fieldsMap, err = BuildEncryptedFieldsMap(collections, keyIDs); err != nil {
+				return fmt.Errorf("build encryptedFieldsMap: %w", err)
+			}
+		}
+		aeOpts.SetEncryptedFieldsMap(fieldsMap)
+	} else {
+		schemaMap := csfle.SchemaMap
+		if schemaMap == nil {
+			if schemaMap, err = BuildSchemaMap(collections, keyIDs); err != nil {
+				return fmt.Errorf("build schemaMap: %w", err)
+			}
+		}
+		aeOpts.SetSchemaMap(schemaMap)
+	}
+
+	clientOptions.SetAutoEncryptionOptions(aeOpts)
+	return nil
+}
+
+// EnsureDataKeys creates (idempotently, keyed by KeyAltName) one data key
+// per distinct KeyAltName referenced by collections' encrypted fields,
+// returning each KeyAltName's data key ID for BuildSchemaMap/
+// BuildEncryptedFieldsMap to embed.
+func EnsureDataKeys(ctx context.Context, keyVaultClient *mongo.Client, csfle config.CSFLEConfig, collections []config.CollectionDefinition) (map[string]bson.Binary, error) {
+	ce, err := mongo.NewClientEncryption(keyVaultClient, options.ClientEncryption().
+		SetKeyVaultNamespace(csfle.KeyVaultNamespace).
+		SetKmsProviders(csfle.KMSProviders))
+	if err != nil {
+		return nil, fmt.Errorf("create ClientEncryption: %w", err)
+	}
+	defer func() { _ = ce.Close(ctx) }()
+
+	ids := make(map[string]bson.Binary)
+	for _, col := range collections {
+		for fname, fdef := range col.Fields {
+			if fdef.Encrypt == nil || fdef.Encrypt.KeyAltName == "" {
+				continue
+			}
+			altName := fdef.Encrypt.KeyAltName
+			if _, ok := ids[altName]; ok {
+				continue
+			}
+			id, err := ensureDataKey(ctx, ce, csfle.KMSProvider, altName)
+			if err != nil {
+				return nil, fmt.Errorf("collection %s field %s: %w", col.Name, fname, err)
+			}
+			ids[altName] = id
+		}
+	}
+	return ids, nil
+}
+
+// ensureDataKey returns altName's existing data key ID, creating one under
+// kmsProvider the first time altName is referenced - idempotent, so
+// re-running the seeder against the same key vault never creates
+// duplicate keys.
+func ensureDataKey(ctx context.Context, ce *mongo.ClientEncryption, kmsProvider, altName string) (bson.Binary, error) {
+	var existing bson.Raw
+	err := ce.GetKeyByAltName(ctx, altName).Decode(&existing)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return bson.Binary{}, fmt.Errorf("look up data key %q: %w", altName, err)
+	}
+	if err == nil {
+		idVal, err := existing.LookupErr("_id")
+		if err != nil {
+			return bson.Binary{}, fmt.Errorf("data key %q missing _id: %w", altName, err)
+		}
+		subtype, data, ok := idVal.BinaryOK()
+		if !ok {
+			return bson.Binary{}, fmt.Errorf("data key %q has a non-binary _id", altName)
+		}
+		return bson.Binary{Subtype: subtype, Data: data}, nil
+	}
+
+	id, err := ce.CreateDataKey(ctx, kmsProvider, options.DataKey().SetKeyAltNames([]string{altName}))
+	if err != nil {
+		return bson.Binary{}, fmt.Errorf("create data key %q: %w", altName, err)
+	}
+	return id, nil
+}
+
+// BuildSchemaMap derives a CSFLE $jsonSchema per collection namespace from
+// each field's Encrypt metadata (QueryType unset - Queryable Encryption
+// fields go through BuildEncryptedFieldsMap instead). Collections/fields
+// without Encrypt set are omitted, same as leaving them out of a
+// hand-authored schemaMap.
+func BuildSchemaMap(collections []config.CollectionDefinition, keyIDs map[string]bson.Binary) (map[string]interface{}, error) {
+	schemaMap := make(map[string]interface{})
+	for _, col := range collections {
+		properties := map[string]interface{}{}
+		for fname, fdef := range col.Fields {
+			if fdef.Encrypt == nil || fdef.Encrypt.QueryType != "" {
+				continue
+			}
+			algo, ok := csfleAlgorithms[fdef.Encrypt.Algorithm]
+			if !ok {
+				return nil, fmt.Errorf("collection %s field %s: unknown CSFLE algorithm %q", col.Name, fname, fdef.Encrypt.Algorithm)
+			}
+			keyID, ok := keyIDs[fdef.Encrypt.KeyAltName]
+			if !ok {
+				return nil, fmt.Errorf("collection %s field %s: no data key for keyAltName %q", col.Name, fname, fdef.Encrypt.KeyAltName)
+			}
+			properties[fname] = map[string]interface{}{
+				"encrypt": map[string]interface{}{
+					"keyId":     []bson.Binary{keyID},
+					"bsonType":  bsonTypeFor(fdef.Type),
+					"algorithm": algo,
+				},
+			}
+		}
+		if len(properties) == 0 {
+			continue
+		}
+		schemaMap[col.DatabaseName+"."+col.Name] = map[string]interface{}{
+			"bsonType":   "object",
+			"properties": properties,
+		}
+	}
+	return schemaMap, nil
+}
+
+// BuildEncryptedFieldsMap derives a Queryable Encryption encryptedFieldsMap
+// per collection namespace from each field's Encrypt metadata (QueryType
+// set). "range" fields carry their RangeMin/RangeMax bounds, so e.g. a
+// duration_minutes-style field can be range-queried without decrypting.
+func BuildEncryptedFieldsMap(collections []config.CollectionDefinition, keyIDs map[string]bson.Binary) (map[string]interface{}, error) {
+	encryptedFieldsMap := make(map[string]interface{})
+	for _, col := range collections {
+		var fields []map[string]interface{}
+		for fname, fdef := range col.Fields {
+			if fdef.Encrypt == nil || fdef.Encrypt.QueryType == "" {
+				continue
+			}
+			keyID, ok := keyIDs[fdef.Encrypt.KeyAltName]
+			if !ok {
+				return nil, fmt.Errorf("collection %s field %s: no data key for keyAltName %q", col.Name, fname, fdef.Encrypt.KeyAltName)
+			}
+
+			query := map[string]interface{}{"queryType": fdef.Encrypt.QueryType}
+			if fdef.Encrypt.Contention != nil {
+				query["contention"] = *fdef.Encrypt.Contention
+			}
+			if fdef.Encrypt.QueryType == "range" {
+				if fdef.Encrypt.RangeMin != nil {
+					query["min"] = *fdef.Encrypt.RangeMin
+				}
+				if fdef.Encrypt.RangeMax != nil {
+					query["max"] = *fdef.Encrypt.RangeMax
+				}
+			}
+
+			fields = append(fields, map[string]interface{}{
+				"path":     fname,
+				"bsonType": bsonTypeFor(fdef.Type),
+				"keyId":    keyID,
+				"queries":  []map[string]interface{}{query},
+			})
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		encryptedFieldsMap[col.DatabaseName+"."+col.Name] = map[string]interface{}{"fields": fields}
+	}
+	return encryptedFieldsMap, nil
+}
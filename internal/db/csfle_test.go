@@ -0,0 +1,142 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestBsonTypeFor(t *testing.T) {
+	cases := map[string]string{
+		"int":       "int",
+		"int32":     "int",
+		"long":      "long",
+		"int64":     "long",
+		"double":    "double",
+		"float":     "double",
+		"decimal":   "decimal",
+		"bool":      "bool",
+		"boolean":   "bool",
+		"date":      "date",
+		"objectid":  "objectId",
+		"string":    "string",
+		"anything?": "string",
+	}
+	for fieldType, want := range cases {
+		if got := bsonTypeFor(fieldType); got != want {
+			t.Errorf("bsonTypeFor(%q) = %q, want %q", fieldType, got, want)
+		}
+	}
+}
+
+func TestBuildSchemaMapSkipsUnencryptedAndQueryableEncryptionFields(t *testing.T) {
+	keyID := bson.Binary{Subtype: 4, Data: []byte("key")}
+	collections := []config.CollectionDefinition{
+		{
+			DatabaseName: "app",
+			Name:         "users",
+			Fields: map[string]config.CollectionField{
+				"email": {Type: "string", Encrypt: &config.EncryptSpec{Algorithm: "deterministic", KeyAltName: "users-key"}},
+				"name":  {Type: "string"},
+				"ssn":   {Type: "string", Encrypt: &config.EncryptSpec{Algorithm: "random", KeyAltName: "users-key", QueryType: "equality"}},
+			},
+		},
+	}
+	keyIDs := map[string]bson.Binary{"users-key": keyID}
+
+	schemaMap, err := BuildSchemaMap(collections, keyIDs)
+	if err != nil {
+		t.Fatalf("BuildSchemaMap() error = %v", err)
+	}
+
+	ns, ok := schemaMap["app.users"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("schemaMap missing app.users entry: %+v", schemaMap)
+	}
+	properties := ns["properties"].(map[string]interface{})
+	if _, ok := properties["email"]; !ok {
+		t.Fatalf("properties missing deterministic-encrypted field %q: %+v", "email", properties)
+	}
+	if _, ok := properties["name"]; ok {
+		t.Fatalf("properties includes unencrypted field %q", "name")
+	}
+	if _, ok := properties["ssn"]; ok {
+		t.Fatalf("properties includes Queryable-Encryption field %q (QueryType set)", "ssn")
+	}
+}
+
+func TestBuildSchemaMapUnknownAlgorithmErrors(t *testing.T) {
+	collections := []config.CollectionDefinition{
+		{
+			DatabaseName: "app",
+			Name:         "users",
+			Fields: map[string]config.CollectionField{
+				"email": {Type: "string", Encrypt: &config.EncryptSpec{Algorithm: "bogus", KeyAltName: "users-key"}},
+			},
+		},
+	}
+	keyIDs := map[string]bson.Binary{"users-key": {}}
+
+	if _, err := BuildSchemaMap(collections, keyIDs); err == nil {
+		t.Fatalf("BuildSchemaMap() with unknown algorithm returned nil error")
+	}
+}
+
+func TestBuildSchemaMapMissingDataKeyErrors(t *testing.T) {
+	collections := []config.CollectionDefinition{
+		{
+			DatabaseName: "app",
+			Name:         "users",
+			Fields: map[string]config.CollectionField{
+				"email": {Type: "string", Encrypt: &config.EncryptSpec{Algorithm: "deterministic", KeyAltName: "missing-key"}},
+			},
+		},
+	}
+
+	if _, err := BuildSchemaMap(collections, map[string]bson.Binary{}); err == nil {
+		t.Fatalf("BuildSchemaMap() with an unresolvable keyAltName returned nil error")
+	}
+}
+
+func TestBuildEncryptedFieldsMapIncludesRangeBounds(t *testing.T) {
+	min, max := 0, 1000
+	keyID := bson.Binary{Subtype: 4, Data: []byte("key")}
+	collections := []config.CollectionDefinition{
+		{
+			DatabaseName: "app",
+			Name:         "orders",
+			Fields: map[string]config.CollectionField{
+				"total": {
+					Type: "int",
+					Encrypt: &config.EncryptSpec{
+						KeyAltName: "orders-key",
+						QueryType:  "range",
+						RangeMin:   &min,
+						RangeMax:   &max,
+					},
+				},
+				"status": {Type: "string"},
+			},
+		},
+	}
+	keyIDs := map[string]bson.Binary{"orders-key": keyID}
+
+	fieldsMap, err := BuildEncryptedFieldsMap(collections, keyIDs)
+	if err != nil {
+		t.Fatalf("BuildEncryptedFieldsMap() error = %v", err)
+	}
+
+	ns, ok := fieldsMap["app.orders"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("encryptedFieldsMap missing app.orders entry: %+v", fieldsMap)
+	}
+	fields := ns["fields"].([]map[string]interface{})
+	if len(fields) != 1 {
+		t.Fatalf("fields = %+v, want exactly 1 (unencrypted field excluded)", fields)
+	}
+	queries := fields[0]["queries"].([]map[string]interface{})
+	if queries[0]["min"] != min || queries[0]["max"] != max {
+		t.Fatalf("queries[0] = %+v, want min=%d max=%d", queries[0], min, max)
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"net/url"
 	"time"
 
-	"github.com/Percona-Lab/mongoDB-workload-generator/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
@@ -28,9 +28,16 @@ func BuildMongoURI(cfg *config.AppConfig) (string, error) {
 
 	// --- Inject Credentials if provided separately ---
 	// This overrides any credentials present in the base URI string.
+	// Password is resolved here, right before it's needed, so a secret
+	// fetched from a file/AWS/GCP/Vault doesn't sit in cfg any longer than
+	// necessary.
 	if cfg.ConnectionParams.Username != "" {
-		if cfg.ConnectionParams.Password != "" {
-			u.User = url.UserPassword(cfg.ConnectionParams.Username, cfg.ConnectionParams.Password)
+		password, err := config.ResolvePassword(&cfg.ConnectionParams)
+		if err != nil {
+			return "", fmt.Errorf("resolve password: %w", err)
+		}
+		if password != "" {
+			u.User = url.UserPassword(cfg.ConnectionParams.Username, password)
 		} else {
 			u.User = url.User(cfg.ConnectionParams.Username)
 		}
@@ -79,7 +86,7 @@ func BuildMongoURI(cfg *config.AppConfig) (string, error) {
 // ---------------------------------------------------------
 // Connect sets driver options + optional debug logging
 // ---------------------------------------------------------
-func Connect(ctx context.Context, cfg *config.AppConfig, dbName string) (*Connection, error) {
+func Connect(ctx context.Context, cfg *config.AppConfig, dbName string, collections []config.CollectionDefinition) (*Connection, error) {
 
 	finalURI, err := BuildMongoURI(cfg)
 	if err != nil {
@@ -94,6 +101,22 @@ func Connect(ctx context.Context, cfg *config.AppConfig, dbName string) (*Connec
 		SetMinPoolSize(uint64(cfg.ConnectionParams.MinPoolSize)).
 		SetMaxConnIdleTime(time.Duration(cfg.ConnectionParams.MaxIdleTime) * time.Minute)
 
+	// AuthMechanism set (MONGODB-AWS, MONGODB-OIDC, ...) overrides the
+	// username/password ApplyURI already baked into finalURI.
+	cred, err := buildCredential(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("build auth credential: %w", err)
+	}
+	if cred != nil {
+		clientOptions.SetAuth(*cred)
+	}
+
+	if cfg.CSFLE.Enabled {
+		if err := setupCSFLE(ctx, cfg, finalURI, clientOptions, collections); err != nil {
+			return nil, fmt.Errorf("setup CSFLE: %w", err)
+		}
+	}
+
 	// -----------------------------------------------------
 	// Connect client
 	// -----------------------------------------------------
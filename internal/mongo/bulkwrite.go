@@ -0,0 +1,104 @@
+package mongo
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// bulkWriteInnerOps is the set of per-document operations a bulkWrite
+// batch draws from, mirroring independentWorker's single-op dispatch.
+var bulkWriteInnerOps = []string{"insert", "updateOne", "updateMany", "deleteOne", "deleteMany"}
+
+// runBulkWrite assembles a batch of mongo.WriteModel values from the same
+// op mix and fallback query generation independentWorker uses one op at a
+// time, then issues them as a single BulkWrite, so single-op vs. batched
+// throughput can be compared directly.
+func runBulkWrite(ctx context.Context, id int, wCfg workloadConfig, rng *rand.Rand, col config.CollectionDefinition) {
+	batchSize := col.BulkWriteBatchSize
+	if batchSize <= 0 {
+		batchSize = wCfg.appConfig.BulkWriteBatchSize
+	}
+	ordered := wCfg.appConfig.BulkWriteOrdered
+	if col.BulkWriteOrdered != nil {
+		ordered = *col.BulkWriteOrdered
+	}
+
+	models := make([]mongo.WriteModel, 0, batchSize)
+	for i := 0; i < batchSize; i++ {
+		// Draw from the configured op mix, keeping only ops a BulkWrite can
+		// carry; fall back to a uniform pick if the mix yields none of them.
+		innerOp := ""
+		for tries := 0; tries < 64 && innerOp == ""; tries++ {
+			switch op := selectOperation(wCfg.picker, rng); op {
+			case "insert", "updateOne", "updateMany", "deleteOne", "deleteMany":
+				innerOp = op
+			}
+		}
+		if innerOp == "" {
+			innerOp = bulkWriteInnerOps[rng.Intn(len(bulkWriteInnerOps))]
+		}
+
+		var q config.QueryDefinition
+		var ok bool
+		if innerOp == "insert" {
+			q, ok = generateInsertQuery(col, rng, wCfg.appConfig), true
+		} else {
+			q, ok = selectRandomQueryByType(ctx, wCfg.database, innerOp, wCfg.queryMap, col, wCfg.debug, rng, wCfg.primaryFilterField, wCfg.appConfig)
+		}
+		if !ok {
+			continue
+		}
+
+		if model := bulkWriteModelFor(innerOp, q, rng, col); model != nil {
+			models = append(models, model)
+		}
+	}
+
+	if len(models) == 0 {
+		return
+	}
+
+	coll := getCollectionHandle(wCfg.database, col)
+	start := time.Now()
+	result, err := coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(ordered))
+	if err != nil {
+		trackOpError(wCfg, id, "bulkWrite", err)
+	}
+	if result != nil {
+		wCfg.collector.TrackBulkWriteResult(id, result.InsertedCount, result.ModifiedCount, result.DeletedCount, result.UpsertedCount)
+	}
+	wCfg.collector.TrackWorker(id, "bulkWrite", time.Since(start))
+}
+
+// bulkWriteModelFor builds the WriteModel for one sampled inner op, using
+// the same cloned/placeholder-resolved filter independentWorker builds
+// for a standalone call to the same op.
+func bulkWriteModelFor(innerOp string, q config.QueryDefinition, rng *rand.Rand, col config.CollectionDefinition) mongo.WriteModel {
+	switch innerOp {
+	case "insert":
+		return mongo.NewInsertOneModel().SetDocument(q.Filter)
+	case "updateOne":
+		filter := cloneMap(q.Filter)
+		processRecursive(filter, rng, col)
+		return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(q.Update).SetUpsert(q.Upsert)
+	case "updateMany":
+		filter := cloneMap(q.Filter)
+		processRecursive(filter, rng, col)
+		return mongo.NewUpdateManyModel().SetFilter(filter).SetUpdate(q.Update).SetUpsert(q.Upsert)
+	case "deleteOne":
+		filter := cloneMap(q.Filter)
+		processRecursive(filter, rng, col)
+		return mongo.NewDeleteOneModel().SetFilter(filter)
+	case "deleteMany":
+		filter := cloneMap(q.Filter)
+		processRecursive(filter, rng, col)
+		return mongo.NewDeleteManyModel().SetFilter(filter)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// changeStreamResumeTokens lets a changeStream worker pick up where a
+// previous iteration left off - even across worker restarts, since it's
+// keyed by namespace rather than by worker id.
+var changeStreamResumeTokens sync.Map
+
+// runChangeStream opens a change stream against col, drains it for a
+// bounded time window or event count (whichever comes first), checkpoints
+// the resume token, and records the iteration's latency under the
+// "changeStream" stats category.
+func runChangeStream(ctx context.Context, id int, wCfg workloadConfig, rng *rand.Rand, col config.CollectionDefinition) {
+	namespace := fmt.Sprintf("%s.%s", col.DatabaseName, col.Name)
+
+	q, _ := selectRandomQueryByType(ctx, wCfg.database, "changeStream", wCfg.queryMap, col, wCfg.debug, rng, wCfg.primaryFilterField, wCfg.appConfig)
+
+	pipeline, ok := deepClone(q.Pipeline).([]interface{})
+	if !ok {
+		pipeline = []interface{}{}
+	}
+	processRecursive(pipeline, rng, col)
+
+	opts := options.ChangeStream().
+		SetFullDocument(fullDocumentOption(q.FullDocument)).
+		SetBatchSize(wCfg.appConfig.ChangeStreamBatchSize)
+	if q.FullDocumentBeforeChange != "" {
+		opts.SetFullDocumentBeforeChange(fullDocumentBeforeChangeOption(q.FullDocumentBeforeChange))
+	}
+	if token, ok := changeStreamResumeTokens.Load(namespace); ok {
+		opts.SetResumeAfter(token)
+	}
+
+	coll := getCollectionHandle(wCfg.database, col)
+
+	start := time.Now()
+	cs, err := coll.Watch(ctx, pipeline, opts)
+	if err != nil {
+		if wCfg.debug {
+			log.Printf("[Worker %d] Watch %s error: %v", id, namespace, err)
+		}
+		return
+	}
+	defer cs.Close(ctx)
+
+	windowCtx, cancel := context.WithTimeout(ctx, time.Duration(wCfg.appConfig.ChangeStreamWindowMs)*time.Millisecond)
+	defer cancel()
+
+	events := 0
+	for events < wCfg.appConfig.ChangeStreamMaxEvents && cs.Next(windowCtx) {
+		events++
+	}
+
+	if token := cs.ResumeToken(); token != nil {
+		changeStreamResumeTokens.Store(namespace, token)
+	}
+
+	wCfg.collector.TrackWorker(id, "changeStream", time.Since(start))
+}
+
+func fullDocumentOption(v string) options.FullDocument {
+	switch v {
+	case "required":
+		return options.Required
+	case "whenAvailable":
+		return options.WhenAvailable
+	case "updateLookup":
+		return options.UpdateLookup
+	default:
+		return options.Default
+	}
+}
+
+func fullDocumentBeforeChangeOption(v string) options.FullDocument {
+	switch v {
+	case "required":
+		return options.Required
+	case "whenAvailable":
+		return options.WhenAvailable
+	default:
+		return options.Off
+	}
+}
@@ -0,0 +1,101 @@
+package mongo
+
+import (
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ErrorCategory is the canonical bucket an operation error is sorted into
+// for stats.Collector.TrackError. Classifying into a small fixed set
+// keeps the periodic monitor and final summary stable across server
+// versions, instead of tracking every raw error code/message.
+type ErrorCategory string
+
+const (
+	ErrDuplicateKey  ErrorCategory = "DUPLICATE_KEY"
+	ErrWriteConflict ErrorCategory = "WRITE_CONFLICT"
+	ErrTransientTxn  ErrorCategory = "TRANSIENT_TXN"
+	ErrTimeout       ErrorCategory = "TIMEOUT"
+	ErrNetwork       ErrorCategory = "NETWORK"
+	ErrValidation    ErrorCategory = "VALIDATION"
+	ErrDecode        ErrorCategory = "DECODE"
+	ErrNotFound      ErrorCategory = "NOT_FOUND"
+	ErrOther         ErrorCategory = "OTHER"
+)
+
+// writeConflictCode and validationCode are the server error codes behind
+// the WRITE_CONFLICT and VALIDATION categories (WriteConflict and
+// DocumentValidationFailure respectively).
+const (
+	writeConflictCode = 112
+	validationCode    = 121
+)
+
+// ClassifyError maps a MongoDB operation error to a canonical
+// ErrorCategory so stats.Collector can track error rates without callers
+// caring about the exact server code/message. err must be non-nil.
+func ClassifyError(err error) ErrorCategory {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotFound
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateKey
+	}
+	if mongo.IsTimeout(err) {
+		return ErrTimeout
+	}
+	if mongo.IsNetworkError(err) {
+		return ErrNetwork
+	}
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		if cmdErr.HasErrorLabel("TransientTransactionError") || cmdErr.HasErrorLabel("UnknownTransactionCommitResult") {
+			return ErrTransientTxn
+		}
+		switch int(cmdErr.Code) {
+		case writeConflictCode:
+			return ErrWriteConflict
+		case validationCode:
+			return ErrValidation
+		}
+	}
+
+	if code, ok := firstWriteErrorCode(err); ok {
+		switch code {
+		case writeConflictCode:
+			return ErrWriteConflict
+		case validationCode:
+			return ErrValidation
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "decod"):
+		return ErrDecode
+	case strings.Contains(msg, "validation"):
+		return ErrValidation
+	case strings.Contains(msg, "deadline exceeded") || strings.Contains(msg, "timeout"):
+		return ErrTimeout
+	}
+
+	return ErrOther
+}
+
+// firstWriteErrorCode pulls the first per-document error code out of a
+// WriteException or BulkWriteException, the shapes InsertMany/UpdateMany/
+// DeleteMany return their per-document failures as.
+func firstWriteErrorCode(err error) (int, bool) {
+	var writeExc mongo.WriteException
+	if errors.As(err, &writeExc) && len(writeExc.WriteErrors) > 0 {
+		return writeExc.WriteErrors[0].Code, true
+	}
+	var bulkExc mongo.BulkWriteException
+	if errors.As(err, &bulkExc) && len(bulkExc.WriteErrors) > 0 {
+		return bulkExc.WriteErrors[0].Code, true
+	}
+	return 0, false
+}
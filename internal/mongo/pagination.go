@@ -0,0 +1,120 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/cursortoken"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// paginationTokens carries a findPaginated worker's keyset cursor from
+// one dispatch to the next, keyed by namespace, so repeated selections of
+// the same collection keep scrolling forward instead of restarting at
+// page one every time.
+var paginationTokens sync.Map
+
+// runFindPaginated drives keyset/cursor-token pagination: each page
+// filters past the previous page's (sortField, _id) instead of the
+// single fixed-limit Find independentWorker otherwise runs, emulating a
+// real infinite-scroll / paginated API workload.
+func runFindPaginated(ctx context.Context, id int, wCfg workloadConfig, rng *rand.Rand, col config.CollectionDefinition) {
+	namespace := fmt.Sprintf("%s.%s", col.DatabaseName, col.Name)
+
+	q, ok := selectRandomQueryByType(ctx, wCfg.database, "findPaginated", wCfg.queryMap, col, wCfg.debug, rng, wCfg.primaryFilterField, wCfg.appConfig)
+	if !ok {
+		q = config.QueryDefinition{
+			Collection: col.Name,
+			Filter:     map[string]interface{}{},
+			Sort:       map[string]interface{}{wCfg.primaryFilterField: 1},
+		}
+	}
+
+	sortField := wCfg.primaryFilterField
+	for k := range q.Sort {
+		sortField = k
+		break
+	}
+
+	pageSize := q.PageSize
+	if pageSize <= 0 {
+		pageSize = int64(wCfg.findBatchSize)
+	}
+	maxPages := q.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+
+	tok := cursortoken.Token{PageSize: pageSize}
+	if encoded, ok := paginationTokens.Load(namespace); ok {
+		if decoded, err := cursortoken.Decode(encoded.(string)); err == nil {
+			tok = decoded
+		}
+	}
+
+	coll := getCollectionHandle(wCfg.database, col)
+	start := time.Now()
+
+	for page := 0; page < maxPages; page++ {
+		filter := cloneMap(q.Filter)
+		processRecursive(filter, rng, col)
+		if filter == nil {
+			filter = map[string]interface{}{}
+		}
+		if tok.LastSortValue != nil && tok.LastID != nil {
+			// A plain {sortField: {$gt: v}, _id: {$gt: id}} AND would skip
+			// every doc that ties on sortField but sorts after id=lastID by
+			// the secondary key, forever - build the standard keyset OR
+			// instead so ties on sortField are still paged through.
+			filter["$or"] = []map[string]interface{}{
+				{sortField: map[string]interface{}{"$gt": tok.LastSortValue}},
+				{sortField: tok.LastSortValue, "_id": map[string]interface{}{"$gt": tok.LastID}},
+			}
+		}
+
+		cursor, err := coll.Find(ctx, filter,
+			options.Find().SetSort(q.Sort).SetLimit(pageSize))
+		if err != nil {
+			if wCfg.debug {
+				log.Printf("[Worker %d] findPaginated %s error: %v", id, namespace, err)
+			}
+			break
+		}
+
+		var lastDoc bson.M
+		count := int64(0)
+		for cursor.Next(ctx) {
+			if err := cursor.Decode(&lastDoc); err == nil {
+				count++
+			}
+		}
+		_ = cursor.Close(ctx)
+
+		if count == 0 {
+			// Reached the end: next dispatch starts over from page one.
+			tok = cursortoken.Token{PageSize: pageSize}
+			break
+		}
+		if v, ok := lastDoc[sortField]; ok {
+			tok.LastSortValue = v
+		}
+		if v, ok := lastDoc["_id"]; ok {
+			tok.LastID = v
+		}
+		if count < pageSize {
+			break
+		}
+	}
+
+	if encoded, err := cursortoken.Encode(tok); err == nil {
+		paginationTokens.Store(namespace, encoded)
+	}
+
+	wCfg.collector.TrackWorker(id, "find", time.Since(start))
+}
@@ -0,0 +1,97 @@
+package mongo
+
+import (
+	"math/rand"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+)
+
+// Picker dispatches config.OpKind values at O(1) per pick, built once at
+// startup via the alias method (Vose's algorithm) from a normalized
+// OperationMix. A new op kind is picked once it is registered in the
+// OperationMix whose Normalize() output is passed to NewPicker; the caller
+// maps it to an operation (see selectOperation).
+type Picker struct {
+	ops   []config.OpKind
+	prob  []float64
+	alias []int
+}
+
+// NewPicker builds a Picker from a normalized weighted-op list (as
+// returned by config.OperationMix.Normalize). Zero-weight ops are
+// dropped since they can never be picked.
+func NewPicker(weighted []config.WeightedOp) *Picker {
+	ops := make([]config.OpKind, 0, len(weighted))
+	weights := make([]float64, 0, len(weighted))
+	total := 0.0
+	for _, w := range weighted {
+		if w.Weight <= 0 {
+			continue
+		}
+		ops = append(ops, w.Op)
+		weights = append(weights, float64(w.Weight))
+		total += float64(w.Weight)
+	}
+
+	n := len(ops)
+	p := &Picker{
+		ops:   ops,
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+	if n == 0 || total <= 0 {
+		return p
+	}
+
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		p.prob[s] = scaled[s]
+		p.alias[s] = l
+
+		scaled[l] = scaled[l] + scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	for _, l := range large {
+		p.prob[l] = 1
+	}
+	for _, s := range small {
+		p.prob[s] = 1
+	}
+
+	return p
+}
+
+// Pick returns an OpKind in O(1), distributed according to the weights
+// NewPicker was built from. An empty Picker always returns "find" so
+// callers never need a nil check.
+func (p *Picker) Pick(rng *rand.Rand) config.OpKind {
+	n := len(p.ops)
+	if n == 0 {
+		return config.OpFind
+	}
+	i := rng.Intn(n)
+	if rng.Float64() < p.prob[i] {
+		return p.ops[i]
+	}
+	return p.ops[p.alias[i]]
+}
@@ -0,0 +1,52 @@
+package mongo
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+)
+
+func TestPickerEmptyAlwaysReturnsFind(t *testing.T) {
+	p := NewPicker(nil)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		if got := p.Pick(rng); got != config.OpFind {
+			t.Fatalf("Pick() on empty Picker = %q, want %q", got, config.OpFind)
+		}
+	}
+}
+
+func TestPickerZeroWeightOpsNeverPicked(t *testing.T) {
+	weighted := []config.WeightedOp{
+		{Op: config.OpFind, Weight: 100},
+		{Op: config.OpDelete, Weight: 0},
+	}
+	p := NewPicker(weighted)
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		if got := p.Pick(rng); got != config.OpFind {
+			t.Fatalf("Pick() = %q, want %q (zero-weight op should never be picked)", got, config.OpFind)
+		}
+	}
+}
+
+func TestPickerDistributionMatchesWeights(t *testing.T) {
+	weighted := []config.WeightedOp{
+		{Op: config.OpFind, Weight: 90},
+		{Op: config.OpInsert, Weight: 10},
+	}
+	p := NewPicker(weighted)
+	rng := rand.New(rand.NewSource(3))
+
+	const n = 100000
+	counts := map[config.OpKind]int{}
+	for i := 0; i < n; i++ {
+		counts[p.Pick(rng)]++
+	}
+
+	findFrac := float64(counts[config.OpFind]) / n
+	if findFrac < 0.85 || findFrac > 0.95 {
+		t.Fatalf("OpFind picked %.3f of draws, want ~0.90", findFrac)
+	}
+}
@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/scenario"
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/stats"
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/workloads"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -31,46 +36,80 @@ type workloadConfig struct {
 	duration           time.Duration
 	collections        []config.CollectionDefinition
 	queryMap           map[string][]config.QueryDefinition
-	percentages        map[string]int
+	picker             *Picker
 	debug              bool
 	findBatchSize      int32
 	findLimit          int64
 	maxInsertCache     int
 	primaryFilterField string
 	collector          *stats.Collector
+	scenarioRunner     *scenario.Runner
+
+	// watchTokenFileMu guards WatchResumeTokenFile, shared by every watch
+	// consumer this workload starts (the continuous WatchWorkers), since
+	// they may all persist to the same file.
+	watchTokenFileMu *sync.Mutex
 }
 
 var InsertDocumentCache chan map[string]interface{}
 
-// base operation types for selection logic
-var operationTypes = []string{"find", "update", "delete", "insert", "insertMany", "aggregate", "transaction"}
+// buildPicker builds a Picker from cfg's (already-normalized) percentage
+// fields; a new op kind is registered in the config.OperationMix built
+// here and mapped to an operation in selectOperation.
+func buildPicker(cfg *config.AppConfig) *Picker {
+	mix := config.OperationMix{
+		config.OpFind:          {Weight: cfg.FindPercent, Pinned: true},
+		config.OpUpdate:        {Weight: cfg.UpdatePercent, Pinned: true},
+		config.OpDelete:        {Weight: cfg.DeletePercent, Pinned: true},
+		config.OpInsert:        {Weight: cfg.InsertPercent, Pinned: true},
+		config.OpInsertMany:    {Weight: cfg.BulkInsertPercent, Pinned: true},
+		config.OpAggregate:     {Weight: cfg.AggregatePercent, Pinned: true},
+		config.OpTransaction:   {Weight: cfg.TransactionPercent, Pinned: true},
+		config.OpChangeStream:  {Weight: cfg.ChangeStreamPercent, Pinned: true},
+		config.OpFindPaginated: {Weight: cfg.FindPaginatedPercent, Pinned: true},
+		config.OpBulkWrite:     {Weight: cfg.BulkWritePercent, Pinned: true},
+		config.OpScenario:      {Weight: cfg.ScenarioPercent, Pinned: true},
+		config.OpWatch:         {Weight: cfg.WatchPercent, Pinned: true},
+	}
+	return NewPicker(mix.Normalize())
+}
 
-func selectOperation(percentages map[string]int, rng *rand.Rand) string {
-	if percentages == nil {
+func selectOperation(picker *Picker, rng *rand.Rand) string {
+	if picker == nil {
 		return "find"
 	}
-	r := rng.Intn(100)
-	cum := 0
-	for _, op := range operationTypes {
-		cum += percentages[op]
-		if r < cum {
-			switch op {
-			case "update":
-				if rng.Intn(100) < 90 {
-					return "updateOne"
-				}
-				return "updateMany"
-			case "delete":
-				if rng.Intn(100) < 90 {
-					return "deleteOne"
-				}
-				return "deleteMany"
-			default:
-				return op
-			}
+	switch picker.Pick(rng) {
+	case config.OpUpdate:
+		if rng.Intn(100) < 90 {
+			return "updateOne"
 		}
+		return "updateMany"
+	case config.OpDelete:
+		if rng.Intn(100) < 90 {
+			return "deleteOne"
+		}
+		return "deleteMany"
+	case config.OpInsert:
+		return "insert"
+	case config.OpInsertMany:
+		return "insertMany"
+	case config.OpAggregate:
+		return "aggregate"
+	case config.OpTransaction:
+		return "transaction"
+	case config.OpChangeStream:
+		return "changeStream"
+	case config.OpFindPaginated:
+		return "findPaginated"
+	case config.OpBulkWrite:
+		return "bulkWrite"
+	case config.OpScenario:
+		return "scenario"
+	case config.OpWatch:
+		return "watch"
+	default:
+		return "find"
 	}
-	return "find"
 }
 
 func getPrimaryFilterField(ctx context.Context, db *mongo.Database, col config.CollectionDefinition) string {
@@ -99,10 +138,19 @@ func generateFallbackQuery(ctx context.Context, db *mongo.Database, opType strin
 	if filterField == "_id" {
 		fieldType = "string"
 	}
+	var fieldDef config.CollectionField
 	if def, ok := col.Fields[filterField]; ok {
 		fieldType = def.Type
+		fieldDef = def
+	}
+
+	var filterVal interface{} = fmt.Sprintf("<%s>", fieldType)
+	if fieldDef.Provider == "ref" {
+		if val, ok := datagen.SampleRef(fieldDef.RefCollection, fieldDef.RefField, fieldDef.RefStrategy, rng); ok {
+			filterVal = val
+		}
 	}
-	filter := map[string]interface{}{filterField: fmt.Sprintf("<%s>", fieldType)}
+	filter := map[string]interface{}{filterField: filterVal}
 
 	if opType == "updateOne" || opType == "updateMany" {
 		updatePayload := workloads.GenerateFallbackUpdate(col, cfg, rng)
@@ -139,7 +187,7 @@ func generateInsertQuery(col config.CollectionDefinition, rng *rand.Rand, cfg *c
 	select {
 	case doc = <-InsertDocumentCache:
 	default:
-		doc = workloads.GenerateDocument(col, cfg)
+		doc = workloads.GenerateDocument(col, cfg, rng)
 	}
 	return config.QueryDefinition{
 		Collection: col.Name,
@@ -155,19 +203,20 @@ func generateInsertManyQuery(col config.CollectionDefinition, rng *rand.Rand, cf
 		select {
 		case docs[i] = <-InsertDocumentCache:
 		default:
-			docs[i] = workloads.GenerateDocument(col, cfg)
+			docs[i] = workloads.GenerateDocument(col, cfg, rng)
 		}
 	}
 	return docs
 }
 
 func insertDocumentProducer(ctx context.Context, col config.CollectionDefinition, cacheSize int, cfg *config.AppConfig) {
+	rng := rand.New(rand.NewSource(datagen.DeriveNamedSeed(datagen.SeedOrTime(cfg.RandomSeed), col.Name)))
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		default:
-			doc := workloads.GenerateDocument(col, cfg)
+			doc := workloads.GenerateDocument(col, cfg, rng)
 			select {
 			case InsertDocumentCache <- doc:
 			case <-ctx.Done():
@@ -182,6 +231,28 @@ func getCollectionHandle(db *mongo.Database, col config.CollectionDefinition) *m
 	return db.Client().Database(col.DatabaseName).Collection(col.Name)
 }
 
+// trackOpError classifies err and records it against opType on the
+// calling worker's shard, so the periodic monitor and final summary can
+// break down failures by category (see mongo.ClassifyError).
+func trackOpError(wCfg workloadConfig, id int, opType string, err error) {
+	wCfg.collector.TrackError(id, opType, string(ClassifyError(err)))
+}
+
+// txnRetryBaseBackoff is the starting backoff between transaction
+// retries; it doubles each attempt (capped at 1s) with up to 50% jitter
+// so concurrent workers contending on the same hot document don't all
+// wake up and retry in lockstep.
+const txnRetryBaseBackoff = 10 * time.Millisecond
+
+func txnRetryBackoff(attempt int, rng *rand.Rand) time.Duration {
+	backoff := txnRetryBaseBackoff * time.Duration(1<<uint(attempt))
+	if backoff > time.Second {
+		backoff = time.Second
+	}
+	jitter := time.Duration(rng.Int63n(int64(backoff)/2 + 1))
+	return backoff + jitter
+}
+
 func runTransaction(ctx context.Context, id int, wCfg workloadConfig, rng *rand.Rand) {
 	session, err := wCfg.database.Client().StartSession()
 	if err != nil {
@@ -190,14 +261,54 @@ func runTransaction(ctx context.Context, id int, wCfg workloadConfig, rng *rand.
 	}
 	defer session.EndSession(ctx)
 
+	txnOpts := buildTransactionOptions(wCfg.appConfig.TxnReadConcern, wCfg.appConfig.TxnWriteConcern)
+	maxRetries := wCfg.appConfig.MaxTxnRetries
 	start := time.Now()
 
-	_, err = session.WithTransaction(ctx, func(sessCtx context.Context) (interface{}, error) {
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			wCfg.collector.TrackTransactionOutcome(id, "timeout")
+			return
+		default:
+		}
+
+		err = runTransactionOnce(ctx, id, wCfg, rng, session, txnOpts)
+		if err == nil {
+			wCfg.collector.TrackTransactionOutcome(id, "committed")
+			wCfg.collector.TrackWorker(id, "transaction", time.Since(start))
+			return
+		}
+
+		if attempt >= maxRetries || ClassifyError(err) != ErrTransientTxn {
+			if wCfg.debug {
+				log.Printf("[Worker %d] Transaction aborted after %d attempt(s): %v", id, attempt+1, err)
+			}
+			trackOpError(wCfg, id, "transaction", err)
+			wCfg.collector.TrackTransactionOutcome(id, "aborted")
+			return
+		}
+
+		wCfg.collector.TrackTransactionOutcome(id, "retried")
+		select {
+		case <-time.After(txnRetryBackoff(attempt, rng)):
+		case <-ctx.Done():
+			wCfg.collector.TrackTransactionOutcome(id, "timeout")
+			return
+		}
+	}
+}
+
+// runTransactionOnce runs a single WithTransaction attempt: a random
+// number of random inner ops against random collections.
+func runTransactionOnce(ctx context.Context, id int, wCfg workloadConfig, rng *rand.Rand, session *mongo.Session, txnOpts *options.TransactionOptionsBuilder) error {
+	_, err := session.WithTransaction(ctx, func(sessCtx context.Context) (interface{}, error) {
+		var err error
 		numOps := rng.Intn(wCfg.appConfig.MaxTransactionOps) + 1
 		for i := 0; i < numOps; i++ {
 			currentCol := wCfg.collections[rng.Intn(len(wCfg.collections))]
-			innerOp := selectOperation(wCfg.percentages, rng)
-			if innerOp == "aggregate" || innerOp == "transaction" {
+			innerOp := selectOperation(wCfg.picker, rng)
+			if innerOp == "aggregate" || innerOp == "transaction" || innerOp == "changeStream" || innerOp == "findPaginated" || innerOp == "bulkWrite" {
 				innerOp = "find"
 			}
 
@@ -223,7 +334,7 @@ func runTransaction(ctx context.Context, id int, wCfg workloadConfig, rng *rand.
 			coll := getCollectionHandle(wCfg.database, currentCol)
 
 			filter := cloneMap(q.Filter)
-			processRecursive(filter, rng)
+			processRecursive(filter, rng, currentCol)
 
 			switch innerOp {
 			case "find":
@@ -250,20 +361,14 @@ func runTransaction(ctx context.Context, id int, wCfg workloadConfig, rng *rand.
 			}
 
 			if err != nil {
+				trackOpError(wCfg, id, innerOp, err)
 				return nil, err
 			}
 		}
 		return nil, nil
-	})
-
-	if err != nil {
-		if wCfg.debug {
-			log.Printf("[Worker %d] Transaction aborted: %v", id, err)
-		}
-		return
-	}
+	}, txnOpts)
 
-	wCfg.collector.Track("transaction", time.Since(start))
+	return err
 }
 
 func independentWorker(ctx context.Context, id int, wg *sync.WaitGroup, wCfg workloadConfig, rng *rand.Rand) {
@@ -278,7 +383,7 @@ func independentWorker(ctx context.Context, id int, wg *sync.WaitGroup, wCfg wor
 		}
 
 		currentCol := wCfg.collections[rng.Intn(len(wCfg.collections))]
-		opType := selectOperation(wCfg.percentages, rng)
+		opType := selectOperation(wCfg.picker, rng)
 
 		if opType == "transaction" {
 			if wCfg.appConfig.UseTransactions {
@@ -288,6 +393,34 @@ func independentWorker(ctx context.Context, id int, wg *sync.WaitGroup, wCfg wor
 			opType = "find"
 		}
 
+		if opType == "changeStream" {
+			runChangeStream(ctx, id, wCfg, rng, currentCol)
+			continue
+		}
+
+		if opType == "findPaginated" {
+			runFindPaginated(ctx, id, wCfg, rng, currentCol)
+			continue
+		}
+
+		if opType == "bulkWrite" {
+			runBulkWrite(ctx, id, wCfg, rng, currentCol)
+			continue
+		}
+
+		if opType == "scenario" {
+			runScenario(ctx, id, wCfg, rng)
+			continue
+		}
+
+		if opType == "watch" {
+			// "watch" is a nominal share of the mix: change-stream
+			// consumption runs in the dedicated WatchWorkers goroutines, so
+			// this iteration does no work of its own.
+			time.Sleep(100 * time.Microsecond)
+			continue
+		}
+
 		var q config.QueryDefinition
 		var insertManyDocs []interface{}
 		var run bool
@@ -318,11 +451,11 @@ func independentWorker(ctx context.Context, id int, wg *sync.WaitGroup, wCfg wor
 		if opType == "aggregate" {
 			if cloned, ok := deepClone(q.Pipeline).([]interface{}); ok {
 				pipeline = cloned
-				processRecursive(pipeline, rng)
+				processRecursive(pipeline, rng, currentCol)
 			}
 		} else if opType != "insertMany" {
 			filter = cloneMap(q.Filter)
-			processRecursive(filter, rng)
+			processRecursive(filter, rng, currentCol)
 		}
 
 		start := time.Now()
@@ -346,6 +479,8 @@ func independentWorker(ctx context.Context, id int, wg *sync.WaitGroup, wCfg wor
 				for cursor.Next(dbOpCtx) {
 				}
 				_ = cursor.Close(dbOpCtx)
+			} else {
+				trackOpError(wCfg, id, opType, err)
 			}
 		case "aggregate":
 			cursor, err := coll.Aggregate(dbOpCtx, pipeline)
@@ -353,30 +488,46 @@ func independentWorker(ctx context.Context, id int, wg *sync.WaitGroup, wCfg wor
 				for cursor.Next(dbOpCtx) {
 				}
 				_ = cursor.Close(dbOpCtx)
+			} else {
+				trackOpError(wCfg, id, opType, err)
 			}
 		case "updateOne":
 			opts := options.UpdateOne().SetUpsert(q.Upsert)
 			_, err := coll.UpdateOne(dbOpCtx, filter, q.Update, opts)
-			if err != nil && wCfg.debug {
-				log.Printf("[Worker %d] UpdateOne error: %v", id, err)
+			if err != nil {
+				if wCfg.debug {
+					log.Printf("[Worker %d] UpdateOne error: %v", id, err)
+				}
+				trackOpError(wCfg, id, opType, err)
 			}
 		case "updateMany":
 			opts := options.UpdateMany().SetUpsert(q.Upsert)
 			_, err := coll.UpdateMany(dbOpCtx, filter, q.Update, opts)
-			if err != nil && wCfg.debug {
-				log.Printf("[Worker %d] UpdateMany error: %v", id, err)
+			if err != nil {
+				if wCfg.debug {
+					log.Printf("[Worker %d] UpdateMany error: %v", id, err)
+				}
+				trackOpError(wCfg, id, opType, err)
 			}
 		case "deleteOne":
-			coll.DeleteOne(dbOpCtx, filter)
+			if _, err := coll.DeleteOne(dbOpCtx, filter); err != nil {
+				trackOpError(wCfg, id, opType, err)
+			}
 		case "deleteMany":
-			coll.DeleteMany(dbOpCtx, filter)
+			if _, err := coll.DeleteMany(dbOpCtx, filter); err != nil {
+				trackOpError(wCfg, id, opType, err)
+			}
 		case "insert":
-			coll.InsertOne(dbOpCtx, q.Filter)
+			if _, err := coll.InsertOne(dbOpCtx, q.Filter); err != nil {
+				trackOpError(wCfg, id, opType, err)
+			}
 		case "insertMany":
-			coll.InsertMany(dbOpCtx, insertManyDocs)
+			if _, err := coll.InsertMany(dbOpCtx, insertManyDocs); err != nil {
+				trackOpError(wCfg, id, opType, err)
+			}
 		}
 
-		wCfg.collector.Track(opType, time.Since(start))
+		wCfg.collector.TrackWorker(id, opType, time.Since(start))
 	}
 }
 
@@ -406,23 +557,48 @@ func cloneMap(m map[string]interface{}) map[string]interface{} {
 	return nil
 }
 
-func processRecursive(v interface{}, rng *rand.Rand) {
+// processRecursive resolves the "<int>"/"<string>" placeholders query
+// authors use for random filter values. col, when non-zero, supplies each
+// field's Min/Max/Distribution so "<int>" samples the same skewed key space
+// the seed data was generated with (see config.Distribution), instead of a
+// flat 0-999 range.
+func processRecursive(v interface{}, rng *rand.Rand, col config.CollectionDefinition) {
 	switch t := v.(type) {
 	case map[string]interface{}:
 		for k, val := range t {
-			if s, ok := val.(string); ok {
-				if s == "<int>" {
-					t[k] = rng.Intn(1000)
-				} else if s == "<string>" {
-					t[k] = fmt.Sprintf("val-%d", rng.Intn(1000))
+			s, ok := val.(string)
+			if !ok {
+				processRecursive(val, rng, col)
+				continue
+			}
+			if s != "<int>" && s != "<string>" {
+				continue
+			}
+
+			fdef := col.Fields[k]
+			if fdef.Provider == "ref" {
+				if refVal, ok := datagen.SampleRef(fdef.RefCollection, fdef.RefField, fdef.RefStrategy, rng); ok {
+					t[k] = refVal
+					continue
+				}
+			}
+
+			if s == "<int>" {
+				min, max := 0, 999
+				if fdef.Min != nil {
+					min = *fdef.Min
+				}
+				if fdef.Max != nil {
+					max = *fdef.Max
 				}
+				t[k] = datagen.SampleInt(fdef.Distribution, rng, min, max, k)
 			} else {
-				processRecursive(val, rng)
+				t[k] = fmt.Sprintf("val-%d", rng.Intn(1000))
 			}
 		}
 	case []interface{}:
 		for _, val := range t {
-			processRecursive(val, rng)
+			processRecursive(val, rng, col)
 		}
 	}
 }
@@ -433,7 +609,10 @@ func RunWorkload(ctx context.Context, db *mongo.Database, collections []config.C
 		return err
 	}
 
-	collector := stats.NewCollector()
+	collector := stats.NewCollectorWithWorkers(cfg.Concurrency)
+	if err := stats.SetupSinksFromConfig(collector, cfg); err != nil {
+		return fmt.Errorf("setup metrics sinks: %w", err)
+	}
 	if duration <= 0 {
 		return runAllQueriesOnce(ctx, db, queries, cfg.DebugMode)
 	}
@@ -454,28 +633,33 @@ func RunWorkload(ctx context.Context, db *mongo.Database, collections []config.C
 
 	cachedFilterField := getPrimaryFilterField(ctx, db, collections[0])
 
+	var scenarioRunner *scenario.Runner
+	if cfg.ScenarioPath != "" {
+		scenarios, err := scenario.LoadScenarios(cfg.ScenarioPath)
+		if err != nil {
+			return fmt.Errorf("load scenarios: %w", err)
+		}
+		if len(scenarios) > 0 {
+			scenarioRunner = scenario.NewRunner(db, scenarios)
+		}
+	}
+
 	wCfg := workloadConfig{
-		database:    db,
-		appConfig:   cfg,
-		concurrency: cfg.Concurrency,
-		duration:    duration,
-		collections: collections,
-		queryMap:    qMap,
-		percentages: map[string]int{
-			"find":        cfg.FindPercent,
-			"update":      cfg.UpdatePercent,
-			"delete":      cfg.DeletePercent,
-			"insert":      cfg.InsertPercent,
-			"insertMany":  cfg.BulkInsertPercent,
-			"aggregate":   cfg.AggregatePercent,
-			"transaction": cfg.TransactionPercent,
-		},
+		database:           db,
+		appConfig:          cfg,
+		concurrency:        cfg.Concurrency,
+		duration:           duration,
+		collections:        collections,
+		queryMap:           qMap,
+		picker:             buildPicker(cfg),
 		debug:              cfg.DebugMode,
+		scenarioRunner:     scenarioRunner,
 		findBatchSize:      findBatch,
 		findLimit:          findLimit,
 		maxInsertCache:     cfg.InsertCacheSize,
 		primaryFilterField: cachedFilterField,
 		collector:          collector,
+		watchTokenFileMu:   &sync.Mutex{},
 	}
 
 	return runContinuousWorkload(ctx, wCfg)
@@ -486,26 +670,66 @@ func runContinuousWorkload(ctx context.Context, wCfg workloadConfig) error {
 	workloadCtx, cancel := context.WithTimeout(ctx, wCfg.duration)
 	defer cancel()
 
+	// A SIGINT/SIGTERM cancels workloadCtx the same way the duration timeout
+	// does, so workers drain through their normal exit path and still get a
+	// real PrintFinalSummary instead of the process dying mid-op.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	interrupted := false
+	go func() {
+		select {
+		case <-sigCh:
+			interrupted = true
+			cancel()
+		case <-workloadCtx.Done():
+		}
+	}()
+
 	for _, col := range wCfg.collections {
 		go insertDocumentProducer(workloadCtx, col, wCfg.maxInsertCache, wCfg.appConfig)
 	}
 
+	if n := wCfg.appConfig.WatchWorkers; n > 0 {
+		for i := 0; i < n; i++ {
+			go runWatchConsumer(workloadCtx, i, wCfg, wCfg.watchTokenFileMu)
+		}
+	}
+
 	monitorDone := make(chan struct{})
 	go func() {
-		wCfg.collector.Monitor(monitorDone, wCfg.appConfig.StatusRefreshRateSec, wCfg.concurrency)
+		if wCfg.appConfig.DashboardMode {
+			wCfg.collector.Dashboard(monitorDone, wCfg.appConfig.StatusRefreshRateSec, wCfg.concurrency, wCfg.duration)
+		} else {
+			wCfg.collector.Monitor(monitorDone, wCfg.appConfig.StatusRefreshRateSec, wCfg.concurrency)
+		}
 	}()
 
+	baseSeed := datagen.SeedOrTime(wCfg.appConfig.RandomSeed)
 	var wg sync.WaitGroup
 	for i := 1; i <= wCfg.concurrency; i++ {
 		wg.Add(1)
-		rng := rand.New(rand.NewSource(time.Now().UnixNano() + int64(i)))
+		rng := rand.New(rand.NewSource(datagen.DeriveWorkerSeed(baseSeed, i)))
 		go independentWorker(workloadCtx, i, &wg, wCfg, rng)
 	}
 
 	<-workloadCtx.Done()
 	wg.Wait()
 	close(monitorDone)
-	wCfg.collector.PrintFinalSummary(wCfg.duration)
+
+	elapsed := time.Since(wCfg.collector.StartTime())
+	if interrupted {
+		fmt.Printf("\n  Aborted after %s of %s (signal received)\n", elapsed.Truncate(time.Second), wCfg.duration)
+		wCfg.collector.PrintFinalSummary(elapsed)
+	} else {
+		wCfg.collector.PrintFinalSummary(wCfg.duration)
+	}
+
+	if rate := wCfg.appConfig.FailOnErrorRate; rate > 0 {
+		if observed := wCfg.collector.ErrorRate(); observed > rate {
+			return fmt.Errorf("error rate %.2f%% exceeded --fail-on-error-rate threshold %.2f%%", observed*100, rate*100)
+		}
+	}
 	return nil
 }
 
@@ -546,7 +770,7 @@ func queryWorkerOnce(ctx context.Context, id int, tasks <-chan *queryTask, wg *s
 		}
 
 		filter := cloneMap(q.Filter)
-		processRecursive(filter, task.rng)
+		processRecursive(filter, task.rng, config.CollectionDefinition{})
 		switch q.Operation {
 		case "find":
 			cursor, _ := coll.Find(dbOpCtx, filter)
@@ -0,0 +1,35 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// runScenario runs one scripted internal/scenario.Scenario through
+// wCfg.scenarioRunner, tracking its total latency like any other op kind.
+// A failed step (driver error) or a failed Expect assertion is recorded as
+// a "scenario" error via trackOpError instead of aborting the workload -
+// this is what lets a scenario reproduce a known-bad sequence over and
+// over without a single bad run stopping the whole test.
+func runScenario(ctx context.Context, id int, wCfg workloadConfig, rng *rand.Rand) {
+	if wCfg.scenarioRunner == nil {
+		return
+	}
+
+	start := time.Now()
+	result := wCfg.scenarioRunner.RunOnce(ctx, rng)
+
+	for _, step := range result.Steps {
+		if step.Err != nil {
+			trackOpError(wCfg, id, "scenario", fmt.Errorf("%s/%s: %w", result.Scenario, step.Step, step.Err))
+			continue
+		}
+		for _, failure := range step.AssertionFailures {
+			trackOpError(wCfg, id, "scenario", fmt.Errorf("%s/%s assertion failed: %s", result.Scenario, step.Step, failure))
+		}
+	}
+
+	wCfg.collector.TrackWorker(id, "scenario", time.Since(start))
+}
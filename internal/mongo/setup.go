@@ -3,9 +3,11 @@ package mongo
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen"
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/logger"
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/workloads"
 
@@ -41,9 +43,11 @@ func InsertRandomDocuments(ctx context.Context, db *mongo.Database, col config.C
 	batch := make([]interface{}, 0, batchSize)
 	totalInserted := 0
 
+	rng := rand.New(rand.NewSource(datagen.DeriveNamedSeed(datagen.SeedOrTime(cfg.RandomSeed), col.Name)))
+
 	for i := 0; i < count; i++ {
 		// Generate document
-		batch = append(batch, workloads.GenerateDocument(col, cfg))
+		batch = append(batch, workloads.GenerateDocument(col, cfg, rng))
 
 		// If batch is full, InsertMany
 		if len(batch) >= batchSize {
@@ -116,21 +120,103 @@ func CreateCollectionsFromConfig(ctx context.Context, db *mongo.Database, cfg *c
 
 			_ = adminDB.RunCommand(ctx, bson.D{{Key: "enableSharding", Value: col.DatabaseName}})
 
+			ns := fmt.Sprintf("%s.%s", col.DatabaseName, col.Name)
 			cmd := bson.D{
-				{Key: "shardCollection", Value: fmt.Sprintf("%s.%s", col.DatabaseName, col.Name)},
+				{Key: "shardCollection", Value: ns},
 				{Key: "key", Value: col.ShardConfig.Key},
 			}
 			if col.ShardConfig.Unique {
 				cmd = append(cmd, bson.E{Key: "unique", Value: true})
 			}
+			if col.ShardConfig.NumInitialChunks > 0 && len(col.ShardConfig.PresplitPoints) == 0 {
+				cmd = append(cmd, bson.E{Key: "numInitialChunks", Value: col.ShardConfig.NumInitialChunks})
+			}
 
 			if err := adminDB.RunCommand(ctx, cmd).Err(); err != nil {
 				logger.Info("Warning: Failed to shard collection '%s': %v", col.Name, err)
-			} else {
-				logger.Info("Sharding configured for '%s' (Key: %v)", col.Name, col.ShardConfig.Key)
+				continue
+			}
+
+			logger.Info("Sharding configured for '%s' (Key: %v)", col.Name, col.ShardConfig.Key)
+
+			if len(col.ShardConfig.PresplitPoints) > 0 {
+				presplitChunks(ctx, adminDB, ns, col.ShardConfig.PresplitPoints)
+			}
+
+			if len(col.ShardConfig.Zones) > 0 {
+				if err := applyZones(ctx, adminDB, ns, col.ShardConfig.Zones); err != nil {
+					logger.Info("Warning: Zone configuration for '%s' rolled back: %v", col.Name, err)
+				} else {
+					logger.Info("Zones configured for '%s' (%d zone(s))", col.Name, len(col.ShardConfig.Zones))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// presplitChunks runs the "split" admin command at each requested point so
+// a freshly sharded collection doesn't start ramp-up with every insert
+// landing on a single chunk. Failures are logged as warnings, matching the
+// style of the sharding setup above, since a missed split point still
+// leaves the collection usable.
+func presplitChunks(ctx context.Context, adminDB *mongo.Database, ns string, points []map[string]interface{}) {
+	for _, point := range points {
+		cmd := bson.D{{Key: "split", Value: ns}, {Key: "middle", Value: point}}
+		if err := adminDB.RunCommand(ctx, cmd).Err(); err != nil {
+			logger.Info("Warning: Failed to pre-split '%s' at %v: %v", ns, point, err)
+		}
+	}
+}
+
+// applyZones assigns each configured zone's shards and key range. If any
+// step fails partway through, everything applied so far is rolled back so
+// the collection isn't left half-configured.
+func applyZones(ctx context.Context, adminDB *mongo.Database, ns string, zones []config.ZoneConfig) error {
+	type shardZone struct{ shard, zone string }
+	var addedShards []shardZone
+	var appliedRanges []config.ZoneConfig
+
+	rollback := func() {
+		for _, z := range appliedRanges {
+			_ = adminDB.RunCommand(ctx, bson.D{
+				{Key: "updateZoneKeyRange", Value: ns},
+				{Key: "min", Value: z.Min},
+				{Key: "max", Value: z.Max},
+				{Key: "zone", Value: nil},
+			}).Err()
+		}
+		for _, sz := range addedShards {
+			_ = adminDB.RunCommand(ctx, bson.D{
+				{Key: "removeShardFromZone", Value: sz.shard},
+				{Key: "zone", Value: sz.zone},
+			}).Err()
+		}
+	}
+
+	for _, zone := range zones {
+		for _, shard := range zone.Shards {
+			cmd := bson.D{{Key: "addShardToZone", Value: shard}, {Key: "zone", Value: zone.Name}}
+			if err := adminDB.RunCommand(ctx, cmd).Err(); err != nil {
+				rollback()
+				return fmt.Errorf("add shard %s to zone %s: %w", shard, zone.Name, err)
 			}
+			addedShards = append(addedShards, shardZone{shard: shard, zone: zone.Name})
 		}
+
+		cmd := bson.D{
+			{Key: "updateZoneKeyRange", Value: ns},
+			{Key: "min", Value: zone.Min},
+			{Key: "max", Value: zone.Max},
+			{Key: "zone", Value: zone.Name},
+		}
+		if err := adminDB.RunCommand(ctx, cmd).Err(); err != nil {
+			rollback()
+			return fmt.Errorf("assign key range for zone %s: %w", zone.Name, err)
+		}
+		appliedRanges = append(appliedRanges, zone)
 	}
+
 	return nil
 }
 
@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/v2/mongo/writeconcern"
+)
+
+// buildTransactionOptions turns cfg.TxnReadConcern/TxnWriteConcern
+// ("majority", "snapshot", "local", ...) into the driver's
+// *options.TransactionOptions, so users can tune isolation/durability
+// without the workload always running with the session defaults. Unknown
+// or empty values fall back to leaving that option unset.
+func buildTransactionOptions(readConcern, writeConcern string) *options.TransactionOptionsBuilder {
+	opts := options.Transaction()
+	if rc := parseReadConcern(readConcern); rc != nil {
+		opts.SetReadConcern(rc)
+	}
+	if wc := parseWriteConcern(writeConcern); wc != nil {
+		opts.SetWriteConcern(wc)
+	}
+	return opts
+}
+
+func parseReadConcern(level string) *readconcern.ReadConcern {
+	switch level {
+	case "majority":
+		return readconcern.Majority()
+	case "snapshot":
+		return readconcern.Snapshot()
+	case "local":
+		return readconcern.Local()
+	case "linearizable":
+		return readconcern.Linearizable()
+	case "available":
+		return readconcern.Available()
+	default:
+		return nil
+	}
+}
+
+func parseWriteConcern(level string) *writeconcern.WriteConcern {
+	switch level {
+	case "majority":
+		return writeconcern.Majority()
+	case "local", "1":
+		return &writeconcern.WriteConcern{W: 1}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,126 @@
+package mongo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// watchResumeTokens is the on-disk shape of cfg.WatchResumeTokenFile: one
+// raw resume token per consumer, keyed by its watchConsumerID so restarting
+// with more or fewer WatchWorkers doesn't mix up tokens between consumers.
+type watchResumeTokens map[string]bson.Raw
+
+func loadWatchResumeTokens(path string) watchResumeTokens {
+	tokens := make(watchResumeTokens)
+	if path == "" {
+		return tokens
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return tokens
+	}
+	_ = json.Unmarshal(b, &tokens)
+	return tokens
+}
+
+// saveWatchResumeToken rewrites the whole resume-token file with token
+// folded in under consumerID. tokenFileMu serializes this across every
+// watch consumer sharing the same file, since each write reads-then-rewrites
+// the full map rather than appending.
+func saveWatchResumeToken(path, consumerID string, token bson.Raw, tokenFileMu *sync.Mutex) {
+	if path == "" {
+		return
+	}
+	tokenFileMu.Lock()
+	defer tokenFileMu.Unlock()
+
+	tokens := loadWatchResumeTokens(path)
+	tokens[consumerID] = token
+	b, err := json.Marshal(tokens)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, b, 0o644)
+}
+
+func watchConsumerID(i int) string {
+	return fmt.Sprintf("watch-%d", i)
+}
+
+// runWatchConsumer opens one long-running change stream (scoped to
+// cfg.WatchCollection, or the whole database when unset) and drains it for
+// the rest of ctx's lifetime, unlike runChangeStream's short, bounded
+// per-iteration cursor. Each event's end-to-end latency - its clusterTime
+// versus the moment this consumer observed it - is recorded under the
+// "watch" stats category, so oplog/CDC pressure shows up the same way any
+// other op's latency does. tokenFileMu is shared by every consumer this
+// workload starts, since they may all persist to the same
+// WatchResumeTokenFile.
+func runWatchConsumer(ctx context.Context, consumerNum int, wCfg workloadConfig, tokenFileMu *sync.Mutex) {
+	cfg := wCfg.appConfig
+	consumerID := watchConsumerID(consumerNum)
+
+	var pipeline mongo.Pipeline
+	if len(cfg.WatchMatchOperationTypes) > 0 {
+		types := make(bson.A, len(cfg.WatchMatchOperationTypes))
+		for i, t := range cfg.WatchMatchOperationTypes {
+			types[i] = t
+		}
+		pipeline = mongo.Pipeline{
+			{{Key: "$match", Value: bson.D{{Key: "operationType", Value: bson.D{{Key: "$in", Value: types}}}}}},
+		}
+	}
+
+	opts := options.ChangeStream().SetFullDocument(fullDocumentOption(cfg.WatchFullDocument))
+	if token, ok := loadWatchResumeTokens(cfg.WatchResumeTokenFile)[consumerID]; ok {
+		opts.SetResumeAfter(token)
+	} else if cfg.WatchStartAtOperationTime != nil {
+		opts.SetStartAtOperationTime(&bson.Timestamp{T: uint32(*cfg.WatchStartAtOperationTime)})
+	}
+
+	var cs *mongo.ChangeStream
+	var err error
+	if cfg.WatchCollection != "" {
+		cs, err = wCfg.database.Collection(cfg.WatchCollection).Watch(ctx, pipeline, opts)
+	} else {
+		cs, err = wCfg.database.Watch(ctx, pipeline, opts)
+	}
+	if err != nil {
+		if wCfg.debug {
+			log.Printf("[Watch %s] open error: %v", consumerID, err)
+		}
+		return
+	}
+	defer cs.Close(ctx)
+
+	for cs.Next(ctx) {
+		receivedAt := time.Now()
+
+		var event struct {
+			ClusterTime bson.Timestamp `bson:"clusterTime"`
+		}
+		if err := cs.Decode(&event); err == nil && event.ClusterTime.T != 0 {
+			writtenAt := time.Unix(int64(event.ClusterTime.T), 0)
+			wCfg.collector.TrackWorker(consumerNum, "watch", receivedAt.Sub(writtenAt))
+		}
+
+		if token := cs.ResumeToken(); token != nil {
+			saveWatchResumeToken(cfg.WatchResumeTokenFile, consumerID, token, tokenFileMu)
+		}
+	}
+
+	if wCfg.debug {
+		if err := cs.Err(); err != nil && ctx.Err() == nil {
+			log.Printf("[Watch %s] stream error: %v", consumerID, err)
+		}
+	}
+}
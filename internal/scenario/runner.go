@@ -0,0 +1,209 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// StepResult is one executed Step's outcome. Err is a hard operation error
+// (the driver call itself failed); AssertionFailures are Expect mismatches,
+// which are reported but never stop the scenario.
+type StepResult struct {
+	Step              string
+	Operation         string
+	Duration          time.Duration
+	Err               error
+	AssertionFailures []string
+}
+
+// Result is one Runner.RunOnce call's outcome: which Scenario ran and how
+// each of its Steps went, in order. Steps stops at the first StepResult
+// with a non-nil Err, since a later step's Filter/Update/Pipeline may
+// depend on a variable the failed step never captured.
+type Result struct {
+	Scenario string
+	Steps    []StepResult
+}
+
+// Runner executes Scenarios against a single database. It holds no
+// per-run state, so one Runner is safely shared by every concurrent
+// workload worker.
+type Runner struct {
+	database  *mongo.Database
+	scenarios []Scenario
+}
+
+// NewRunner builds a Runner over scenarios, executed against database.
+func NewRunner(database *mongo.Database, scenarios []Scenario) *Runner {
+	return &Runner{database: database, scenarios: scenarios}
+}
+
+// RunOnce picks a random Scenario and runs it start to finish, threading
+// captured variables between steps.
+func (r *Runner) RunOnce(ctx context.Context, rng *rand.Rand) Result {
+	sc := r.scenarios[rng.Intn(len(r.scenarios))]
+	result := Result{Scenario: sc.Name}
+
+	vars := make(map[string]interface{})
+	for i, step := range sc.Steps {
+		label := step.Name
+		if label == "" {
+			label = fmt.Sprintf("step%d", i+1)
+		}
+
+		start := time.Now()
+		fields, err := r.runStep(ctx, step, vars)
+		sr := StepResult{Step: label, Operation: step.Operation, Duration: time.Since(start), Err: err}
+
+		if err == nil {
+			for varName, source := range step.Capture {
+				if val, ok := fields[source]; ok {
+					vars[varName] = val
+				}
+			}
+			sr.AssertionFailures = checkExpectations(step.Expect, fields)
+		}
+
+		result.Steps = append(result.Steps, sr)
+		if err != nil {
+			break
+		}
+	}
+
+	return result
+}
+
+// runStep executes a single step and returns the result fields Capture/
+// Expect can reference (e.g. "inserted_id", "matched_count", "count").
+func (r *Runner) runStep(ctx context.Context, step Step, vars map[string]interface{}) (map[string]interface{}, error) {
+	coll := r.database.Collection(step.Collection)
+
+	switch step.Operation {
+	case "insert":
+		doc := substituteMap(step.Document, vars)
+		res, err := coll.InsertOne(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"inserted_id": res.InsertedID}, nil
+
+	case "find":
+		filter := substituteMap(step.Filter, vars)
+		cursor, err := coll.Find(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = cursor.Close(ctx) }()
+
+		var count int64
+		var first bson.M
+		for cursor.Next(ctx) {
+			if count == 0 {
+				_ = cursor.Decode(&first)
+			}
+			count++
+		}
+		fields := map[string]interface{}{"count": count}
+		for k, v := range first {
+			fields[k] = v
+		}
+		return fields, nil
+
+	case "updateOne", "updateMany":
+		filter := substituteMap(step.Filter, vars)
+		update := substituteMap(step.Update, vars)
+
+		var matched, modified int64
+		var upsertedID interface{}
+		if step.Operation == "updateOne" {
+			res, err := coll.UpdateOne(ctx, filter, update)
+			if err != nil {
+				return nil, err
+			}
+			matched, modified, upsertedID = res.MatchedCount, res.ModifiedCount, res.UpsertedID
+		} else {
+			res, err := coll.UpdateMany(ctx, filter, update)
+			if err != nil {
+				return nil, err
+			}
+			matched, modified, upsertedID = res.MatchedCount, res.ModifiedCount, res.UpsertedID
+		}
+
+		fields := map[string]interface{}{"matched_count": matched, "modified_count": modified}
+		if upsertedID != nil {
+			fields["upserted_id"] = upsertedID
+		}
+		return fields, nil
+
+	case "deleteOne", "deleteMany":
+		filter := substituteMap(step.Filter, vars)
+		var deleted int64
+		var err error
+		if step.Operation == "deleteOne" {
+			var res *mongo.DeleteResult
+			res, err = coll.DeleteOne(ctx, filter)
+			if res != nil {
+				deleted = res.DeletedCount
+			}
+		} else {
+			var res *mongo.DeleteResult
+			res, err = coll.DeleteMany(ctx, filter)
+			if res != nil {
+				deleted = res.DeletedCount
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"deleted_count": deleted}, nil
+
+	case "aggregate":
+		pipeline := substitute(step.Pipeline, vars)
+		cursor, err := coll.Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = cursor.Close(ctx) }()
+
+		var count int64
+		var first bson.M
+		for cursor.Next(ctx) {
+			if count == 0 {
+				_ = cursor.Decode(&first)
+			}
+			count++
+		}
+		fields := map[string]interface{}{"count": count}
+		for k, v := range first {
+			fields[k] = v
+		}
+		return fields, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported scenario step operation %q", step.Operation)
+	}
+}
+
+// checkExpectations compares expect against a step's result fields,
+// returning one description per mismatch (missing field or differing
+// value). Values are compared via their fmt.Sprintf("%v", ...) form so a
+// YAML-parsed int and a driver-returned int64 count as equal.
+func checkExpectations(expect map[string]interface{}, fields map[string]interface{}) []string {
+	var failures []string
+	for key, want := range expect {
+		got, ok := fields[key]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s: expected %v, field not present in result", key, want))
+			continue
+		}
+		if fmt.Sprintf("%v", want) != fmt.Sprintf("%v", got) {
+			failures = append(failures, fmt.Sprintf("%s: expected %v, got %v", key, want, got))
+		}
+	}
+	return failures
+}
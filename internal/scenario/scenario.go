@@ -0,0 +1,133 @@
+// Package scenario implements scripted, dialog-style workloads: an ordered
+// sequence of steps (e.g. insert order -> find order by id -> update status
+// -> aggregate totals) run against a single virtual user, with values
+// captured from one step's result and reused in a later step's filter/
+// update/pipeline. It's driven from internal/mongo alongside the existing
+// random-op mix, for reproducing a specific customer-reported sequence
+// instead of relying on chance to hit it.
+package scenario
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Step is one action in a Scenario: which operation to run against which
+// collection, optional document/filter/update/pipeline (each may contain
+// "{{varName}}" tokens resolved from values captured by earlier steps),
+// which result fields to capture into named variables, and which result
+// fields to assert on.
+type Step struct {
+	// Name labels this step in StepResult/logs; defaults to "step<N>" (1-based) when empty.
+	Name       string                 `yaml:"name,omitempty"`
+	Operation  string                 `yaml:"operation"` // insert, find, updateOne, updateMany, deleteOne, deleteMany, aggregate
+	Collection string                 `yaml:"collection"`
+	Document   map[string]interface{} `yaml:"document,omitempty"`
+	Filter     map[string]interface{} `yaml:"filter,omitempty"`
+	Update     map[string]interface{} `yaml:"update,omitempty"`
+	Pipeline   []interface{}          `yaml:"pipeline,omitempty"`
+
+	// Capture maps a variable name to a field this step's result exposes
+	// (e.g. "inserted_id", "matched_count", "modified_count",
+	// "deleted_count", "count", or - for aggregate - a field name from the
+	// first result document). Later steps reference the variable as
+	// "{{varName}}" in Filter/Update/Pipeline/Document.
+	Capture map[string]string `yaml:"capture,omitempty"`
+
+	// Expect asserts result fields (same names Capture draws from) after
+	// the step runs, e.g. {matched_count: 1}. A mismatch is recorded as an
+	// assertion failure on the StepResult, not an error - it doesn't stop
+	// the scenario or the workload.
+	Expect map[string]interface{} `yaml:"expect,omitempty"`
+}
+
+// Scenario is a named, ordered sequence of Steps run as one unit by Runner.RunOnce.
+type Scenario struct {
+	Name  string `yaml:"name"`
+	Steps []Step `yaml:"steps"`
+}
+
+type scenariosFile struct {
+	Scenarios []Scenario `yaml:"scenarios"`
+}
+
+// LoadScenarios reads path as YAML, accepting either {scenarios: [...]} or
+// a bare top-level list, mirroring config.LoadCollections/LoadQueries's
+// "wrapped object, else bare array" parsing. An empty path disables
+// scripted scenarios entirely (nil, nil).
+func LoadScenarios(path string) ([]Scenario, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scenarios file: %w", err)
+	}
+
+	var wrapped scenariosFile
+	if err := yaml.Unmarshal(b, &wrapped); err == nil && len(wrapped.Scenarios) > 0 {
+		return wrapped.Scenarios, nil
+	}
+
+	var arr []Scenario
+	if err := yaml.Unmarshal(b, &arr); err != nil {
+		return nil, fmt.Errorf("invalid scenarios format in %s: %w", path, err)
+	}
+	return arr, nil
+}
+
+var (
+	exactVarPattern = regexp.MustCompile(`^\{\{(\w+)\}\}$`)
+	embedVarPattern = regexp.MustCompile(`\{\{(\w+)\}\}`)
+)
+
+// substitute resolves "{{varName}}" tokens against vars. A value that is
+// exactly one token ("{{order_id}}") is replaced with the captured value
+// as-is (preserving its type, e.g. an ObjectID); a token embedded in a
+// larger string is replaced with its fmt.Sprintf("%v", ...) form. Unknown
+// variables are left untouched so a typo is visible in the issued query
+// rather than silently turning into an empty string.
+func substitute(v interface{}, vars map[string]interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		if m := exactVarPattern.FindStringSubmatch(t); m != nil {
+			if val, ok := vars[m[1]]; ok {
+				return val
+			}
+			return t
+		}
+		return embedVarPattern.ReplaceAllStringFunc(t, func(match string) string {
+			name := embedVarPattern.FindStringSubmatch(match)[1]
+			if val, ok := vars[name]; ok {
+				return fmt.Sprintf("%v", val)
+			}
+			return match
+		})
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = substitute(val, vars)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = substitute(val, vars)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func substituteMap(m map[string]interface{}, vars map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	res, _ := substitute(m, vars).(map[string]interface{})
+	return res
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,118 +17,296 @@ import (
 	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/logger"
 )
 
-const MaxLatencyBin = 10000
+// opShard holds one worker's share of the counters/histograms. A worker
+// writes to the shard at its id modulo the shard count, so counters are
+// atomics and each histogram's mutex is rarely contended on the hot path;
+// Monitor/PrintFinalSummary read them from other
+// goroutines with atomic loads and LatencyHistogram.Merge respectively.
+type opShard struct {
+	FindOps         uint64
+	InsertOps       uint64
+	UpdateOps       uint64
+	DeleteOps       uint64
+	AggOps          uint64
+	TransOps        uint64
+	ChangeStreamOps uint64
+	BulkWriteOps    uint64
+	ScenarioOps     uint64
+	WatchOps        uint64
 
-type LatencyHistogram struct {
-	mu       sync.Mutex
-	Buckets  [MaxLatencyBin]int64
-	Overflow int64
-	Count    int64
-	Sum      float64
-	Min      float64
-	Max      float64
-}
+	// BulkWrite per-suboperation counters, pulled from each call's
+	// BulkWriteResult so single-op vs. batched throughput is comparable.
+	BulkInserted uint64
+	BulkModified uint64
+	BulkDeleted  uint64
+	BulkUpserted uint64
 
-func (h *LatencyHistogram) Record(ms float64) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-	h.Count++
-	h.Sum += ms
-	if ms < h.Min {
-		h.Min = ms
-	}
-	if ms > h.Max {
-		h.Max = ms
-	}
-	bucket := int(math.Round(ms))
-	if bucket < 0 {
-		bucket = 0
-	}
-	if bucket >= MaxLatencyBin {
-		h.Overflow++
-	} else {
-		h.Buckets[bucket]++
-	}
+	// Transaction outcome counters, broken out from TransOps/TransHist
+	// (which only ever record committed attempts): TxnRetried double-counts
+	// with a later TxnCommitted/TxnAborted, since it tracks retry pressure
+	// across the whole attempt, not a final outcome.
+	TxnCommitted uint64
+	TxnAborted   uint64
+	TxnRetried   uint64
+	TxnTimeout   uint64
+
+	FindHist         *LatencyHistogram
+	InsertHist       *LatencyHistogram
+	UpdateHist       *LatencyHistogram
+	DeleteHist       *LatencyHistogram
+	AggHist          *LatencyHistogram
+	TransHist        *LatencyHistogram
+	ChangeStreamHist *LatencyHistogram
+	BulkWriteHist    *LatencyHistogram
+	ScenarioHist     *LatencyHistogram
+	WatchHist        *LatencyHistogram
+
+	// errMu guards errors: unlike the counters above, error categories
+	// are open-ended (op x category), so they don't fit the fixed atomic
+	// fields this struct otherwise uses.
+	errMu  sync.Mutex
+	errors map[string]map[string]uint64 // op -> category -> count
 }
 
-func (h *LatencyHistogram) GetPercentile(p float64) float64 {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-	if h.Count == 0 {
-		return 0.0
-	}
-	targetCount := int64(math.Ceil((p / 100.0) * float64(h.Count)))
-	var currentCount int64 = 0
-	for i, count := range h.Buckets {
-		currentCount += count
-		if currentCount >= targetCount {
-			return float64(i)
-		}
+func newOpShard() *opShard {
+	return &opShard{
+		FindHist:         &LatencyHistogram{Min: math.MaxFloat64},
+		InsertHist:       &LatencyHistogram{Min: math.MaxFloat64},
+		UpdateHist:       &LatencyHistogram{Min: math.MaxFloat64},
+		DeleteHist:       &LatencyHistogram{Min: math.MaxFloat64},
+		AggHist:          &LatencyHistogram{Min: math.MaxFloat64},
+		TransHist:        &LatencyHistogram{Min: math.MaxFloat64},
+		ChangeStreamHist: &LatencyHistogram{Min: math.MaxFloat64},
+		BulkWriteHist:    &LatencyHistogram{Min: math.MaxFloat64},
+		ScenarioHist:     &LatencyHistogram{Min: math.MaxFloat64},
+		WatchHist:        &LatencyHistogram{Min: math.MaxFloat64},
 	}
-	return float64(MaxLatencyBin)
 }
 
 type Collector struct {
-	FindOps   uint64
-	InsertOps uint64
-	UpdateOps uint64
-	DeleteOps uint64
-	AggOps    uint64
-	TransOps  uint64
-
-	FindHist   *LatencyHistogram
-	InsertHist *LatencyHistogram
-	UpdateHist *LatencyHistogram
-	DeleteHist *LatencyHistogram
-	AggHist    *LatencyHistogram
-	TransHist  *LatencyHistogram
-
-	startTime  time.Time
-	prevFind   uint64
-	prevInsert uint64
-	prevUpdate uint64
-	prevDelete uint64
-	prevAgg    uint64
-	prevTrans  uint64
+	shards []*opShard
+	// rrCounter backs the Track() convenience path when no worker id is
+	// available, spreading those calls across shards round-robin.
+	rrCounter uint64
+
+	startTime        time.Time
+	prevFind         uint64
+	prevInsert       uint64
+	prevUpdate       uint64
+	prevDelete       uint64
+	prevAgg          uint64
+	prevTrans        uint64
+	prevChangeStream uint64
+	prevBulkWrite    uint64
+	prevErrors       uint64
+
+	sinks []MetricsSink
 }
 
+// NewCollector creates a collector with a single shard. Use
+// NewCollectorWithWorkers when the worker count is known, so each worker
+// gets its own uncontended shard.
 func NewCollector() *Collector {
+	return NewCollectorWithWorkers(1)
+}
+
+// NewCollectorWithWorkers creates a collector with one shard per worker.
+func NewCollectorWithWorkers(numWorkers int) *Collector {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	shards := make([]*opShard, numWorkers)
+	for i := range shards {
+		shards[i] = newOpShard()
+	}
 	return &Collector{
-		FindHist:   &LatencyHistogram{Min: math.MaxFloat64},
-		InsertHist: &LatencyHistogram{Min: math.MaxFloat64},
-		UpdateHist: &LatencyHistogram{Min: math.MaxFloat64},
-		DeleteHist: &LatencyHistogram{Min: math.MaxFloat64},
-		AggHist:    &LatencyHistogram{Min: math.MaxFloat64},
-		TransHist:  &LatencyHistogram{Min: math.MaxFloat64},
-		startTime:  time.Now(),
+		shards:    shards,
+		startTime: time.Now(),
 	}
 }
 
-func (c *Collector) Track(opType string, duration time.Duration) {
-	ms := float64(duration.Nanoseconds()) / 1e6
+// StartTime returns when the collector was created, so callers outside this
+// package (e.g. an interrupted runContinuousWorkload) can report elapsed
+// runtime without reaching into an unexported field.
+func (c *Collector) StartTime() time.Time {
+	return c.startTime
+}
+
+func (c *Collector) shardFor(workerID int) *opShard {
+	return c.shards[workerID%len(c.shards)]
+}
+
+// TrackWorker records an operation against the calling worker's own shard,
+// so concurrent workers never contend on the same histogram mutex.
+func (c *Collector) TrackWorker(workerID int, opType string, duration time.Duration) {
+	shard := c.shardFor(workerID)
+
 	switch opType {
 	case "find":
-		atomic.AddUint64(&c.FindOps, 1)
-		c.FindHist.Record(ms)
+		atomic.AddUint64(&shard.FindOps, 1)
+		shard.FindHist.Record(duration)
 	case "insert":
-		atomic.AddUint64(&c.InsertOps, 1)
-		c.InsertHist.Record(ms)
+		atomic.AddUint64(&shard.InsertOps, 1)
+		shard.InsertHist.Record(duration)
 	case "updateOne", "updateMany":
-		atomic.AddUint64(&c.UpdateOps, 1)
-		c.UpdateHist.Record(ms)
+		atomic.AddUint64(&shard.UpdateOps, 1)
+		shard.UpdateHist.Record(duration)
 	case "deleteOne", "deleteMany":
-		atomic.AddUint64(&c.DeleteOps, 1)
-		c.DeleteHist.Record(ms)
+		atomic.AddUint64(&shard.DeleteOps, 1)
+		shard.DeleteHist.Record(duration)
 	case "aggregate":
-		atomic.AddUint64(&c.AggOps, 1)
-		c.AggHist.Record(ms)
+		atomic.AddUint64(&shard.AggOps, 1)
+		shard.AggHist.Record(duration)
 	case "transaction":
-		atomic.AddUint64(&c.TransOps, 1)
-		c.TransHist.Record(ms)
+		atomic.AddUint64(&shard.TransOps, 1)
+		shard.TransHist.Record(duration)
+	case "changeStream":
+		atomic.AddUint64(&shard.ChangeStreamOps, 1)
+		shard.ChangeStreamHist.Record(duration)
+	case "bulkWrite":
+		atomic.AddUint64(&shard.BulkWriteOps, 1)
+		shard.BulkWriteHist.Record(duration)
+	case "scenario":
+		atomic.AddUint64(&shard.ScenarioOps, 1)
+		shard.ScenarioHist.Record(duration)
+	case "watch":
+		atomic.AddUint64(&shard.WatchOps, 1)
+		shard.WatchHist.Record(duration)
+	}
+
+	ms := float64(duration.Nanoseconds()) / 1e6
+	for _, sink := range c.sinks {
+		sink.Observe(opType, ms)
+	}
+}
+
+// TrackTransactionOutcome records one of a transaction attempt's
+// outcomes: "committed", "aborted", "retried", or "timeout" (see
+// internal/mongo's runTransaction), so users can see the retry pressure
+// behind their TransactionPercent mix.
+func (c *Collector) TrackTransactionOutcome(workerID int, outcome string) {
+	shard := c.shardFor(workerID)
+	switch outcome {
+	case "committed":
+		atomic.AddUint64(&shard.TxnCommitted, 1)
+	case "aborted":
+		atomic.AddUint64(&shard.TxnAborted, 1)
+	case "retried":
+		atomic.AddUint64(&shard.TxnRetried, 1)
+	case "timeout":
+		atomic.AddUint64(&shard.TxnTimeout, 1)
+	}
+}
+
+// TrackBulkWriteResult folds one BulkWrite call's per-suboperation counts
+// (from its driver BulkWriteResult) into the calling worker's shard, so
+// single-op vs. batched throughput can be compared in the final summary.
+func (c *Collector) TrackBulkWriteResult(workerID int, inserted, modified, deleted, upserted int64) {
+	shard := c.shardFor(workerID)
+	atomic.AddUint64(&shard.BulkInserted, uint64(inserted))
+	atomic.AddUint64(&shard.BulkModified, uint64(modified))
+	atomic.AddUint64(&shard.BulkDeleted, uint64(deleted))
+	atomic.AddUint64(&shard.BulkUpserted, uint64(upserted))
+}
+
+// TrackError records that op failed with the given category (e.g.
+// "DUPLICATE_KEY", "WRITE_CONFLICT" — see mongo.ErrorCategory), on top of
+// the attempt TrackWorker already counted for the same op.
+func (c *Collector) TrackError(workerID int, op, category string) {
+	shard := c.shardFor(workerID)
+
+	shard.errMu.Lock()
+	if shard.errors == nil {
+		shard.errors = make(map[string]map[string]uint64)
+	}
+	if shard.errors[op] == nil {
+		shard.errors[op] = make(map[string]uint64)
+	}
+	shard.errors[op][category]++
+	shard.errMu.Unlock()
+}
+
+// errorSummary merges every shard's op -> category -> count map into one.
+func (c *Collector) errorSummary() map[string]map[string]uint64 {
+	merged := make(map[string]map[string]uint64)
+	for _, shard := range c.shards {
+		shard.errMu.Lock()
+		for op, cats := range shard.errors {
+			if merged[op] == nil {
+				merged[op] = make(map[string]uint64)
+			}
+			for cat, n := range cats {
+				merged[op][cat] += n
+			}
+		}
+		shard.errMu.Unlock()
+	}
+	return merged
+}
+
+// TotalErrors sums every tracked error across all ops and categories.
+func (c *Collector) TotalErrors() uint64 {
+	var total uint64
+	for _, cats := range c.errorSummary() {
+		for _, n := range cats {
+			total += n
+		}
+	}
+	return total
+}
+
+// TotalOps sums every attempted operation (successful or not) across all
+// op types.
+func (c *Collector) TotalOps() uint64 {
+	return c.sumOps(func(s *opShard) *uint64 { return &s.FindOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.InsertOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.UpdateOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.DeleteOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.AggOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.TransOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.ChangeStreamOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.BulkWriteOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.ScenarioOps }) +
+		c.sumOps(func(s *opShard) *uint64 { return &s.WatchOps })
+}
+
+// ErrorRate returns TotalErrors/TotalOps, or 0 if no ops have run yet.
+func (c *Collector) ErrorRate() float64 {
+	total := c.TotalOps()
+	if total == 0 {
+		return 0
+	}
+	return float64(c.TotalErrors()) / float64(total)
+}
+
+// Track is a convenience wrapper for callers with no worker id to hand in
+// (e.g. one-off queries); it round-robins across shards so it still avoids
+// pinning every caller onto the same shard.
+func (c *Collector) Track(opType string, duration time.Duration) {
+	id := int(atomic.AddUint64(&c.rrCounter, 1))
+	c.TrackWorker(id, opType, duration)
+}
+
+// sumOps totals a counter across all shards via atomic.LoadUint64.
+func (c *Collector) sumOps(get func(*opShard) *uint64) uint64 {
+	var total uint64
+	for _, shard := range c.shards {
+		total += atomic.LoadUint64(get(shard))
+	}
+	return total
+}
+
+// mergedHist folds every shard's histogram for one op type into a single
+// histogram for percentile computation.
+func (c *Collector) mergedHist(get func(*opShard) *LatencyHistogram) *LatencyHistogram {
+	merged := &LatencyHistogram{Min: math.MaxFloat64}
+	for _, shard := range c.shards {
+		merged.Merge(get(shard))
 	}
+	return merged
 }
 
-const monitorLayout = " %-7s | %10s | %8s | %8s | %8s | %8s | %6s | %6s\n"
+const monitorLayout = " %-7s | %10s | %8s | %8s | %8s | %8s | %6s | %6s | %6s | %6s | %6s\n"
 
 func (c *Collector) Monitor(done <-chan struct{}, refreshRateSec int, concurrency int) {
 	ticker := time.NewTicker(time.Duration(refreshRateSec) * time.Second)
@@ -137,11 +316,11 @@ func (c *Collector) Monitor(done <-chan struct{}, refreshRateSec int, concurrenc
 	fmt.Println(logger.GreenString("> Starting Workload..."))
 	fmt.Println()
 
-	header := fmt.Sprintf(monitorLayout, "TIME", "TOTAL OPS", "SELECT", "INSERT", "UPDATE", "DELETE", "AGG", "TRANS")
+	header := fmt.Sprintf(monitorLayout, "TIME", "TOTAL OPS", "SELECT", "INSERT", "UPDATE", "DELETE", "AGG", "TRANS", "CHGSTM", "BULK", "ERRORS")
 	fmt.Print(logger.BoldString(header))
 
 	fmt.Println(logger.CyanString(
-		" -------------------------------------------------------------------------------",
+		" ----------------------------------------------------------------------------------------",
 	))
 
 	for {
@@ -155,12 +334,15 @@ func (c *Collector) Monitor(done <-chan struct{}, refreshRateSec int, concurrenc
 }
 
 func (c *Collector) printInterval() {
-	cF := atomic.LoadUint64(&c.FindOps)
-	cI := atomic.LoadUint64(&c.InsertOps)
-	cU := atomic.LoadUint64(&c.UpdateOps)
-	cD := atomic.LoadUint64(&c.DeleteOps)
-	cA := atomic.LoadUint64(&c.AggOps)
-	cT := atomic.LoadUint64(&c.TransOps)
+	cF := c.sumOps(func(s *opShard) *uint64 { return &s.FindOps })
+	cI := c.sumOps(func(s *opShard) *uint64 { return &s.InsertOps })
+	cU := c.sumOps(func(s *opShard) *uint64 { return &s.UpdateOps })
+	cD := c.sumOps(func(s *opShard) *uint64 { return &s.DeleteOps })
+	cA := c.sumOps(func(s *opShard) *uint64 { return &s.AggOps })
+	cT := c.sumOps(func(s *opShard) *uint64 { return &s.TransOps })
+	cC := c.sumOps(func(s *opShard) *uint64 { return &s.ChangeStreamOps })
+	cB := c.sumOps(func(s *opShard) *uint64 { return &s.BulkWriteOps })
+	cE := c.TotalErrors()
 
 	dF := cF - c.prevFind
 	dI := cI - c.prevInsert
@@ -168,11 +350,17 @@ func (c *Collector) printInterval() {
 	dD := cD - c.prevDelete
 	dA := cA - c.prevAgg
 	dT := cT - c.prevTrans
+	dC := cC - c.prevChangeStream
+	dB := cB - c.prevBulkWrite
+	dE := cE - c.prevErrors
 
 	c.prevFind, c.prevInsert, c.prevUpdate = cF, cI, cU
 	c.prevDelete, c.prevAgg, c.prevTrans = cD, cA, cT
+	c.prevChangeStream = cC
+	c.prevBulkWrite = cB
+	c.prevErrors = cE
 
-	totalDelta := dF + dI + dU + dD + dA + dT
+	totalDelta := dF + dI + dU + dD + dA + dT + dC + dB
 
 	elapsed := time.Since(c.startTime).Truncate(time.Second)
 	elapsedStr := fmt.Sprintf("%02d:%02d", int(elapsed.Minutes()), int(elapsed.Seconds())%60)
@@ -189,12 +377,14 @@ func (c *Collector) printInterval() {
 		formatInt(int64(dD)),
 		formatInt(int64(dA)),
 		formatInt(int64(dT)),
+		formatInt(int64(dC)),
+		formatInt(int64(dB)),
+		formatInt(int64(dE)),
 	)
 }
 
 func (c *Collector) PrintFinalSummary(duration time.Duration) {
-	fO, iO, uO, dO, aO, tO := atomic.LoadUint64(&c.FindOps), atomic.LoadUint64(&c.InsertOps), atomic.LoadUint64(&c.UpdateOps), atomic.LoadUint64(&c.DeleteOps), atomic.LoadUint64(&c.AggOps), atomic.LoadUint64(&c.TransOps)
-	totalOps := fO + iO + uO + dO + aO + tO
+	totalOps := c.TotalOps()
 	seconds := duration.Seconds()
 
 	fmt.Println()
@@ -217,12 +407,99 @@ func (c *Collector) PrintFinalSummary(duration time.Duration) {
 	fmt.Println(logger.CyanString("  --------------------------------------------------"))
 	const layout = "  %-7s   %10s   %10s   %10s   %10s   %10s"
 	fmt.Println(logger.BoldString(fmt.Sprintf(layout, "TYPE", "AVG", "MIN", "MAX", "P95", "P99")))
-	printLatencyRow(layout, "SELECT", c.FindHist)
-	printLatencyRow(layout, "INSERT", c.InsertHist)
-	printLatencyRow(layout, "UPDATE", c.UpdateHist)
-	printLatencyRow(layout, "DELETE", c.DeleteHist)
-	printLatencyRow(layout, "AGG", c.AggHist)
-	printLatencyRow(layout, "TRANS", c.TransHist)
+	printLatencyRow(layout, "SELECT", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.FindHist }))
+	printLatencyRow(layout, "INSERT", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.InsertHist }))
+	printLatencyRow(layout, "UPDATE", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.UpdateHist }))
+	printLatencyRow(layout, "DELETE", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.DeleteHist }))
+	printLatencyRow(layout, "AGG", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.AggHist }))
+	printLatencyRow(layout, "TRANS", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.TransHist }))
+	printLatencyRow(layout, "CHGSTM", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.ChangeStreamHist }))
+	printLatencyRow(layout, "BULK", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.BulkWriteHist }))
+	printLatencyRow(layout, "SCENARIO", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.ScenarioHist }))
+	printLatencyRow(layout, "WATCH", c.mergedHist(func(s *opShard) *LatencyHistogram { return s.WatchHist }))
+	fmt.Println()
+
+	c.printTransactionOutcomes()
+	c.printBulkWriteBreakdown()
+	printErrorSummary(c.errorSummary())
+}
+
+// printBulkWriteBreakdown renders the per-suboperation counts behind
+// BulkWriteOps, so single-op vs. batched throughput is comparable.
+// Skipped if no bulkWrite was ever attempted.
+func (c *Collector) printBulkWriteBreakdown() {
+	inserted := c.sumOps(func(s *opShard) *uint64 { return &s.BulkInserted })
+	modified := c.sumOps(func(s *opShard) *uint64 { return &s.BulkModified })
+	deleted := c.sumOps(func(s *opShard) *uint64 { return &s.BulkDeleted })
+	upserted := c.sumOps(func(s *opShard) *uint64 { return &s.BulkUpserted })
+	if inserted+modified+deleted+upserted == 0 {
+		return
+	}
+
+	fmt.Println(logger.BoldString("  BULK WRITE"))
+	fmt.Println(logger.CyanString("  --------------------------------------------------"))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  Inserted:\t%s\n", formatInt(int64(inserted)))
+	fmt.Fprintf(w, "  Modified:\t%s\n", formatInt(int64(modified)))
+	fmt.Fprintf(w, "  Deleted:\t%s\n", formatInt(int64(deleted)))
+	fmt.Fprintf(w, "  Upserted:\t%s\n", formatInt(int64(upserted)))
+	w.Flush()
+	fmt.Println()
+}
+
+// printTransactionOutcomes renders the committed/aborted/retried/timeout
+// breakdown behind TransOps. Skipped if no transaction was ever attempted.
+func (c *Collector) printTransactionOutcomes() {
+	committed := c.sumOps(func(s *opShard) *uint64 { return &s.TxnCommitted })
+	aborted := c.sumOps(func(s *opShard) *uint64 { return &s.TxnAborted })
+	retried := c.sumOps(func(s *opShard) *uint64 { return &s.TxnRetried })
+	timedOut := c.sumOps(func(s *opShard) *uint64 { return &s.TxnTimeout })
+	if committed+aborted+retried+timedOut == 0 {
+		return
+	}
+
+	fmt.Println(logger.BoldString("  TRANSACTIONS"))
+	fmt.Println(logger.CyanString("  --------------------------------------------------"))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  Committed:\t%s\n", formatInt(int64(committed)))
+	fmt.Fprintf(w, "  Aborted:\t%s\n", formatInt(int64(aborted)))
+	fmt.Fprintf(w, "  Retried:\t%s\n", formatInt(int64(retried)))
+	fmt.Fprintf(w, "  Timed out:\t%s\n", formatInt(int64(timedOut)))
+	w.Flush()
+	fmt.Println()
+}
+
+// printErrorSummary renders the per-op error category breakdown, e.g.
+//
+//	updateOne: 34 DUPLICATE_KEY, 5 WRITE_CONFLICT
+//
+// Ops with no tracked errors are omitted.
+func printErrorSummary(summary map[string]map[string]uint64) {
+	if len(summary) == 0 {
+		return
+	}
+
+	ops := make([]string, 0, len(summary))
+	for op := range summary {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	fmt.Println(logger.BoldString("  ERRORS"))
+	fmt.Println(logger.CyanString("  --------------------------------------------------"))
+	for _, op := range ops {
+		cats := make([]string, 0, len(summary[op]))
+		for cat := range summary[op] {
+			cats = append(cats, cat)
+		}
+		sort.Strings(cats)
+
+		parts := make([]string, 0, len(cats))
+		for _, cat := range cats {
+			parts = append(parts, fmt.Sprintf("%s %s", formatInt(int64(summary[op][cat])), cat))
+		}
+		fmt.Printf("  %s: %s\n", op, strings.Join(parts, ", "))
+	}
 	fmt.Println()
 }
 
@@ -296,6 +573,13 @@ func PrintConfiguration(appCfg *config.AppConfig, collections []config.Collectio
 	fmt.Fprintf(w, "  Distribution:\tSelect (%d%%)\tUpdate (%d%%)\n", appCfg.FindPercent, appCfg.UpdatePercent)
 	fmt.Fprintf(w, "  \tInsert (%d%%)\tDelete (%d%%)\n", appCfg.InsertPercent, appCfg.DeletePercent)
 	fmt.Fprintf(w, "  \tAgg    (%d%%)\tTrans  (%d%%)\n", appCfg.AggregatePercent, appCfg.TransactionPercent)
+	fmt.Fprintf(w, "  \tChangeStream (%d%%)\tBulkWrite (%d%%)\n", appCfg.ChangeStreamPercent, appCfg.BulkWritePercent)
+	if appCfg.ScenarioPath != "" {
+		fmt.Fprintf(w, "  \tScenario (%d%%)\t%s\n", appCfg.ScenarioPercent, appCfg.ScenarioPath)
+	}
+	if appCfg.WatchWorkers > 0 {
+		fmt.Fprintf(w, "  \tWatch (%d%%)\t%d consumer(s)\n", appCfg.WatchPercent, appCfg.WatchWorkers)
+	}
 	w.Flush()
 	fmt.Println()
 }
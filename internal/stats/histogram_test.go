@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetPercentileMonotonic(t *testing.T) {
+	h := &LatencyHistogram{}
+	for i := 1; i <= 1000; i++ {
+		h.Record(time.Duration(i) * time.Microsecond)
+	}
+
+	p50 := h.GetPercentile(50)
+	p99 := h.GetPercentile(99)
+	if p50 <= 0 {
+		t.Fatalf("p50 = %v, want > 0", p50)
+	}
+	if p99 < p50 {
+		t.Fatalf("p99 (%v) < p50 (%v)", p99, p50)
+	}
+	if p99 > h.Max {
+		t.Fatalf("p99 (%v) > Max (%v)", p99, h.Max)
+	}
+}
+
+func TestGetPercentileEmptyHistogram(t *testing.T) {
+	h := &LatencyHistogram{}
+	if got := h.GetPercentile(50); got != 0 {
+		t.Fatalf("GetPercentile on empty histogram = %v, want 0", got)
+	}
+}
+
+func TestMergeCombinesCountsAndExtremes(t *testing.T) {
+	a := &LatencyHistogram{}
+	b := &LatencyHistogram{}
+
+	for i := 1; i <= 10; i++ {
+		a.Record(time.Duration(i) * time.Millisecond)
+	}
+	for i := 11; i <= 20; i++ {
+		b.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	a.Merge(b)
+
+	if a.Count != 20 {
+		t.Fatalf("Count after merge = %d, want 20", a.Count)
+	}
+	if math.Abs(a.Max-20) > 0.001 {
+		t.Fatalf("Max after merge = %v, want ~20", a.Max)
+	}
+}
+
+func TestMergeConcurrentWithRecordIsRaceFree(t *testing.T) {
+	src := &LatencyHistogram{}
+	dst := &LatencyHistogram{}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 2000; i++ {
+			src.Record(time.Duration(i) * time.Microsecond)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			dst.Merge(src)
+		}
+	}()
+	wg.Wait()
+}
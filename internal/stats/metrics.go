@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsSink receives a copy of every tracked operation so external
+// systems (Prometheus, StatsD, ...) can observe the workload without the
+// console Monitor having to know about them.
+type MetricsSink interface {
+	Observe(opType string, ms float64)
+}
+
+// PrometheusSink exposes an HTTP /metrics endpoint with an op counter and
+// a latency histogram. The histogram has its own exponential buckets
+// (20 buckets from 0.05ms, doubling); it does not share the console
+// summary's LatencyHistogram buckets.
+type PrometheusSink struct {
+	opsTotal *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// NewPrometheusSink registers its collectors and starts serving /metrics on
+// listenAddr (e.g. ":9090"). It does not block.
+func NewPrometheusSink(listenAddr, prefix string) (*PrometheusSink, error) {
+	reg := prometheus.NewRegistry()
+
+	sink := &PrometheusSink{
+		opsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: prefix + "_ops_total",
+			Help: "Total number of operations tracked by the collector, by op type.",
+		}, []string{"op"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    prefix + "_op_latency_ms",
+			Help:    "Operation latency in milliseconds, by op type.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 20),
+		}, []string{"op"}),
+	}
+
+	reg.MustRegister(sink.opsTotal, sink.latency)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s for /metrics: %w", listenAddr, err)
+	}
+
+	go func() {
+		if err := http.Serve(ln, mux); err != nil {
+			logger.Info("Prometheus exporter stopped: %v", err)
+		}
+	}()
+
+	logger.Info("Prometheus exporter listening on %s/metrics", listenAddr)
+	return sink, nil
+}
+
+func (s *PrometheusSink) Observe(opType string, ms float64) {
+	s.opsTotal.WithLabelValues(opType).Inc()
+	s.latency.WithLabelValues(opType).Observe(ms)
+}
+
+// StatsdSink pushes one counter and one timing line per operation to a
+// configured UDP endpoint using the plain StatsD wire format.
+type StatsdSink struct {
+	conn   net.Conn
+	prefix string
+}
+
+// NewStatsdSink dials addr (host:port) over UDP. The connection is
+// connectionless/non-blocking, so a down collector never stalls workers.
+func NewStatsdSink(addr, prefix string) (*StatsdSink, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial statsd endpoint %s: %w", addr, err)
+	}
+	logger.Info("StatsD exporter pushing to %s (prefix=%s)", addr, prefix)
+	return &StatsdSink{conn: conn, prefix: prefix}, nil
+}
+
+func (s *StatsdSink) Observe(opType string, ms float64) {
+	// best-effort, fire-and-forget: a lost UDP datagram must never slow a worker down.
+	_, _ = fmt.Fprintf(s.conn, "%s.ops.%s:1|c", s.prefix, opType)
+	_, _ = fmt.Fprintf(s.conn, "%s.latency.%s:%.3f|ms", s.prefix, opType, ms)
+}
+
+// AddSink registers an additional metrics sink. Track() fans every recorded
+// operation out to all registered sinks in addition to the in-process
+// histograms the console monitor reads.
+func (c *Collector) AddSink(sink MetricsSink) {
+	c.sinks = append(c.sinks, sink)
+}
+
+// SetupSinksFromConfig wires the Prometheus/StatsD exporters declared in
+// AppConfig onto the collector. Either, both, or neither may be configured;
+// the console monitor keeps working unchanged regardless.
+func SetupSinksFromConfig(c *Collector, cfg *config.AppConfig) error {
+	prefix := cfg.MetricsPrefix
+	if prefix == "" {
+		prefix = "plgm"
+	}
+
+	if cfg.MetricsListen != "" {
+		sink, err := NewPrometheusSink(cfg.MetricsListen, prefix)
+		if err != nil {
+			return err
+		}
+		c.AddSink(sink)
+	}
+
+	if cfg.StatsdAddr != "" {
+		sink, err := NewStatsdSink(cfg.StatsdAddr, prefix)
+		if err != nil {
+			return err
+		}
+		c.AddSink(sink)
+	}
+
+	return nil
+}
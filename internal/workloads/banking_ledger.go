@@ -0,0 +1,84 @@
+package workloads
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+var ledgerTransactionTypes = []string{"deposit", "withdrawal", "transfer", "fee", "interest"}
+var ledgerCurrencies = []string{"USD", "EUR", "GBP", "JPY"}
+
+// debitTransactionTypes are the transaction_type values PostProcess forces
+// amount negative for; everything else is forced positive, so a reader
+// summing a ledger's "amount" column gets a correct running balance.
+var debitTransactionTypes = map[string]bool{"withdrawal": true, "fee": true}
+
+// bankingLedgerWorkload implements Workload for an account-transaction
+// scenario: registered under "banking_ledger", selected via a collection's
+// WorkloadKind.
+type bankingLedgerWorkload struct{}
+
+func init() {
+	Register("banking_ledger", bankingLedgerWorkload{})
+}
+
+func (bankingLedgerWorkload) Name() string { return "banking_ledger" }
+
+// randomAccountNumber produces an 11-digit account number, e.g.
+// "ACCT-00471982635".
+func randomAccountNumber(faker *gofakeit.Faker) string {
+	return fmt.Sprintf("ACCT-%011d", faker.Number(0, 99999999999))
+}
+
+func (bankingLedgerWorkload) GenerateDocument(col config.CollectionDefinition, rng *rand.Rand) map[string]interface{} {
+	faker := datagen.NewFaker(rng.Int63())
+	doc := make(map[string]interface{})
+
+	if len(col.Fields) == 0 {
+		doc["account_number"] = randomAccountNumber(faker)
+		doc["transaction_type"] = ledgerTransactionTypes[rng.Intn(len(ledgerTransactionTypes))]
+		doc["currency"] = ledgerCurrencies[rng.Intn(len(ledgerCurrencies))]
+		doc["amount"] = float64(rng.Intn(100000)) / 100.0
+	} else {
+		for fname, fdef := range col.Fields {
+			switch {
+			case fdef.Provider == "account_number":
+				doc[fname] = randomAccountNumber(faker)
+			case fdef.Provider == "ledger_transaction_type" || fname == "transaction_type":
+				doc[fname] = ledgerTransactionTypes[rng.Intn(len(ledgerTransactionTypes))]
+			case fname == "currency":
+				doc[fname] = ledgerCurrencies[rng.Intn(len(ledgerCurrencies))]
+			default:
+				doc[fname] = datagen.RandomValueWithFaker(fdef, faker, fname)
+			}
+		}
+	}
+	return doc
+}
+
+func (bankingLedgerWorkload) GenerateUpdate(rng *rand.Rand) map[string]interface{} {
+	return map[string]interface{}{
+		"$set": map[string]interface{}{"transaction_type": ledgerTransactionTypes[rng.Intn(len(ledgerTransactionTypes))]},
+	}
+}
+
+// PostProcess enforces the sign of "amount" matches "transaction_type":
+// debits (withdrawal/fee) negative, everything else positive.
+func (bankingLedgerWorkload) PostProcess(doc map[string]interface{}, rng *rand.Rand) error {
+	txnType, _ := doc["transaction_type"].(string)
+	amount, ok := doc["amount"].(float64)
+	if !ok {
+		return nil
+	}
+	if debitTransactionTypes[txnType] {
+		doc["amount"] = -math.Abs(amount)
+	} else {
+		doc["amount"] = math.Abs(amount)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package workloads
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+var orderStatuses = []string{"pending", "paid", "fulfilled", "shipped", "delivered", "cancelled", "refunded"}
+
+const minOrderItems = 1
+const maxOrderItems = 5
+
+// ecommerceOrdersWorkload implements Workload for an order-line-item
+// scenario: registered under "ecommerce_orders", selected via a
+// collection's WorkloadKind.
+type ecommerceOrdersWorkload struct{}
+
+func init() {
+	Register("ecommerce_orders", ecommerceOrdersWorkload{})
+}
+
+func (ecommerceOrdersWorkload) Name() string { return "ecommerce_orders" }
+
+// randomSKU produces a product SKU, e.g. "SKU-QhTzKa".
+func randomSKU(faker *gofakeit.Faker) string {
+	return fmt.Sprintf("SKU-%s", faker.LetterN(6))
+}
+
+// randomOrderItems builds the order's line items and returns them alongside
+// the subtotal they imply, so GenerateDocument can set "total" consistently
+// without a separate pass over the document.
+func randomOrderItems(rng *rand.Rand, faker *gofakeit.Faker) ([]map[string]interface{}, float64) {
+	numItems := rng.Intn(maxOrderItems-minOrderItems+1) + minOrderItems
+	items := make([]map[string]interface{}, numItems)
+	var subtotal float64
+	for i := 0; i < numItems; i++ {
+		qty := rng.Intn(5) + 1
+		unitPrice := float64(rng.Intn(9900)+100) / 100.0
+		items[i] = map[string]interface{}{
+			"sku":        randomSKU(faker),
+			"quantity":   qty,
+			"unit_price": unitPrice,
+		}
+		subtotal += float64(qty) * unitPrice
+	}
+	return items, subtotal
+}
+
+func (ecommerceOrdersWorkload) GenerateDocument(col config.CollectionDefinition, rng *rand.Rand) map[string]interface{} {
+	faker := datagen.NewFaker(rng.Int63())
+	doc := make(map[string]interface{})
+
+	if len(col.Fields) == 0 {
+		items, subtotal := randomOrderItems(rng, faker)
+		doc["order_id"] = faker.UUID()
+		doc["customer_email"] = faker.Email()
+		doc["items"] = items
+		doc["total"] = subtotal
+		doc["status"] = orderStatuses[rng.Intn(len(orderStatuses))]
+		return doc
+	}
+
+	var items []map[string]interface{}
+	var subtotal float64
+	haveItems := false
+	for fname, fdef := range col.Fields {
+		switch {
+		case fdef.Provider == "sku":
+			doc[fname] = randomSKU(faker)
+		case fdef.Provider == "order_status":
+			doc[fname] = orderStatuses[rng.Intn(len(orderStatuses))]
+		case fname == "items":
+			items, subtotal = randomOrderItems(rng, faker)
+			doc[fname] = items
+			haveItems = true
+		case fname == "total":
+			continue // set below, once subtotal is known
+		default:
+			doc[fname] = datagen.RandomValueWithFaker(fdef, faker, fname)
+		}
+	}
+	if _, ok := col.Fields["total"]; ok {
+		if haveItems {
+			doc["total"] = subtotal
+		} else {
+			doc["total"] = float64(rng.Intn(50000)) / 100.0
+		}
+	}
+	return doc
+}
+
+func (ecommerceOrdersWorkload) GenerateUpdate(rng *rand.Rand) map[string]interface{} {
+	return map[string]interface{}{
+		"$set": map[string]interface{}{"status": orderStatuses[rng.Intn(len(orderStatuses))]},
+	}
+}
@@ -4,11 +4,9 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
-	"time"
 
-	"github.com/Percona-Lab/mongoDB-workload-generator/internal/config"
-	"github.com/Percona-Lab/mongoDB-workload-generator/internal/datagen"
-	"github.com/brianvoe/gofakeit/v6"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen"
 )
 
 // simplified but realistic plane types & amenities
@@ -37,7 +35,7 @@ func randomEquipment(rng *rand.Rand) map[string]interface{} {
 
 // randomPassengers creates a list of passengers with UNIQUE seat assignments
 func randomPassengers(totalSeats int, seatsAvailable int, rng *rand.Rand) []map[string]interface{} {
-	faker := gofakeit.New(rng.Int63())
+	faker := datagen.NewFaker(rng.Int63())
 	numPassengers := totalSeats - seatsAvailable
 	if numPassengers < 1 {
 		numPassengers = 1
@@ -84,10 +82,30 @@ func randomPassengers(totalSeats int, seatsAvailable int, rng *rand.Rand) []map[
 	return passengers
 }
 
-// GenerateDefaultDocument produces a document using the collection def if provided.
-func GenerateDefaultDocument(col config.CollectionDefinition) map[string]interface{} {
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	faker := gofakeit.New(rng.Int63()) // Create ONCE
+// flightsWorkload implements Workload for the bundled "flights" scenario.
+// It's registered under the name "flights" so collections opt in via
+// WorkloadKind: "flights" instead of the old col.Name == "flights" check.
+type flightsWorkload struct{}
+
+func init() {
+	Register("flights", flightsWorkload{})
+}
+
+func (flightsWorkload) Name() string { return "flights" }
+
+func (flightsWorkload) GenerateDocument(col config.CollectionDefinition, rng *rand.Rand) map[string]interface{} {
+	return GenerateDefaultDocument(col, rng)
+}
+
+func (flightsWorkload) GenerateUpdate(rng *rand.Rand) map[string]interface{} {
+	return GenerateDefaultUpdate(rng)
+}
+
+// GenerateDefaultDocument produces a document using the collection def if
+// provided. rng is the caller's (worker-scoped) RNG, so a seeded run
+// reproduces the same flight documents every time.
+func GenerateDefaultDocument(col config.CollectionDefinition, rng *rand.Rand) map[string]interface{} {
+	faker := datagen.NewFaker(rng.Int63()) // Create ONCE
 	doc := make(map[string]interface{})
 
 	if len(col.Fields) > 0 {
@@ -123,7 +141,7 @@ func GenerateDefaultDocument(col config.CollectionDefinition) map[string]interfa
 				continue // handled at the end
 			default:
 				// 3. Fallback to Generic Generator using EXISTING faker
-				doc[fname] = datagen.RandomValueWithFaker(fdef, faker)
+				doc[fname] = datagen.RandomValueWithFaker(fdef, faker, fname)
 			}
 		}
 
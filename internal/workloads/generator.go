@@ -2,37 +2,63 @@ package workloads
 
 import (
 	"math/rand"
-	"time"
 
-	"github.com/Percona-Lab/mongoDB-workload-generator/internal/config"
-	"github.com/Percona-Lab/mongoDB-workload-generator/internal/datagen"
-	"github.com/brianvoe/gofakeit/v6"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen"
 )
 
-// GenerateDocument creates a single document.
-func GenerateDocument(col config.CollectionDefinition, cfg *config.AppConfig) map[string]interface{} {
+// workloadKind resolves which registered Workload (if any) should generate
+// col's documents/updates. WorkloadKind is the current way to select one;
+// a bare col.Name == "flights" under DefaultWorkload is kept working for
+// collections authored before WorkloadKind existed.
+func workloadKind(col config.CollectionDefinition, cfg *config.AppConfig) string {
+	if col.WorkloadKind != "" {
+		return col.WorkloadKind
+	}
 	if cfg.DefaultWorkload && col.Name == "flights" {
-		return GenerateDefaultDocument(col)
+		return "flights"
 	}
-	return generateGenericDocument(col)
+	return ""
+}
+
+// GenerateDocument creates a single document. rng drives both the
+// generic/domain field generation below and the Faker it builds, so a
+// seeded rng (see config.AppConfig.RandomSeed) reproduces the exact same
+// document stream across runs.
+func GenerateDocument(col config.CollectionDefinition, cfg *config.AppConfig, rng *rand.Rand) map[string]interface{} {
+	var doc map[string]interface{}
+	if w, ok := Lookup(workloadKind(col, cfg)); ok {
+		doc = w.GenerateDocument(col, rng)
+		if pp, ok := w.(PostProcessor); ok {
+			// Best-effort: a fixup failure shouldn't block document
+			// generation, so the error is dropped rather than surfaced.
+			_ = pp.PostProcess(doc, rng)
+		}
+	} else {
+		doc = generateGenericDocument(col, rng)
+	}
+	// Record the generated fields so "ref" provider fields on other
+	// collections can later sample a key that actually exists.
+	datagen.RecordDocumentFields(col.Name, doc)
+	return doc
 }
 
 // GenerateFallbackUpdate creates an update document when no configured query is found.
 func GenerateFallbackUpdate(col config.CollectionDefinition, cfg *config.AppConfig, rng *rand.Rand) map[string]interface{} {
-	if cfg.DefaultWorkload && col.Name == "flights" {
-		return GenerateDefaultUpdate(rng)
+	if w, ok := Lookup(workloadKind(col, cfg)); ok {
+		return w.GenerateUpdate(rng)
 	}
 	return generateGenericUpdate(col, rng)
 }
 
-func generateGenericDocument(col config.CollectionDefinition) map[string]interface{} {
+func generateGenericDocument(col config.CollectionDefinition, rng *rand.Rand) map[string]interface{} {
 	// Optimization: Create ONE faker instance per document
-	faker := gofakeit.New(time.Now().UnixNano())
+	faker := datagen.NewFaker(rng.Int63())
 
 	doc := make(map[string]interface{})
 	for fieldName, fieldDef := range col.Fields {
 		// Pass the faker instance to reuse RNG
-		doc[fieldName] = datagen.RandomValueWithFaker(fieldDef, faker)
+		doc[fieldName] = datagen.RandomValueWithFaker(fieldDef, faker, fieldName)
 	}
 	return doc
 }
@@ -51,9 +77,9 @@ func generateGenericUpdate(col config.CollectionDefinition, rng *rand.Rand) map[
 	randomField := keys[rng.Intn(len(keys))]
 	fieldDef := col.Fields[randomField]
 
-	// For single field updates, we can create a temporary faker wrapping the existing RNG
-	// or just make a new one (updates are less frequent than inserts usually)
-	val := datagen.RandomValue(fieldDef)
+	// For single field updates, reuse the caller's rng so a seeded run
+	// reproduces the same update values too.
+	val := datagen.RandomValueWithFaker(fieldDef, datagen.NewFaker(rng.Int63()), randomField)
 
 	return map[string]interface{}{
 		"$set": map[string]interface{}{randomField: val},
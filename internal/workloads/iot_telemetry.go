@@ -0,0 +1,95 @@
+package workloads
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/datagen"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+// telemetryMetrics pairs each metric name with the unit its value is in, so
+// randomMetric can pick one and keep the two fields consistent.
+var telemetryMetrics = []struct {
+	name string
+	unit string
+}{
+	{"temperature", "celsius"},
+	{"humidity", "percent"},
+	{"pressure", "hpa"},
+	{"vibration", "mm_per_s"},
+	{"co2", "ppm"},
+}
+
+// iotTelemetryWorkload implements Workload for a device-reading scenario:
+// registered under "iot_telemetry", selected via a collection's WorkloadKind.
+type iotTelemetryWorkload struct{}
+
+func init() {
+	Register("iot_telemetry", iotTelemetryWorkload{})
+}
+
+func (iotTelemetryWorkload) Name() string { return "iot_telemetry" }
+
+// randomDeviceID produces a device identifier, e.g. "dev-kPqRsTuV".
+func randomDeviceID(faker *gofakeit.Faker) string {
+	return fmt.Sprintf("dev-%s", faker.LetterN(8))
+}
+
+// randomMetric picks a metric/unit/value reading as a group, since the unit
+// has to match whichever metric was picked.
+func randomMetric(rng *rand.Rand) (metric string, unit string, value float64) {
+	m := telemetryMetrics[rng.Intn(len(telemetryMetrics))]
+	return m.name, m.unit, rng.Float64() * 100
+}
+
+func (iotTelemetryWorkload) GenerateDocument(col config.CollectionDefinition, rng *rand.Rand) map[string]interface{} {
+	faker := datagen.NewFaker(rng.Int63())
+	doc := make(map[string]interface{})
+
+	if len(col.Fields) == 0 {
+		metric, unit, value := randomMetric(rng)
+		doc["device_id"] = randomDeviceID(faker)
+		doc["metric"] = metric
+		doc["unit"] = unit
+		doc["value"] = value
+		doc["battery_pct"] = rng.Intn(101)
+		return doc
+	}
+
+	var metric, unit string
+	var value float64
+	haveReading := false
+	for fname, fdef := range col.Fields {
+		switch {
+		case fdef.Provider == "device_id":
+			doc[fname] = randomDeviceID(faker)
+		case fname == "metric" || fname == "unit" || fname == "value":
+			if !haveReading {
+				metric, unit, value = randomMetric(rng)
+				haveReading = true
+			}
+			switch fname {
+			case "metric":
+				doc[fname] = metric
+			case "unit":
+				doc[fname] = unit
+			case "value":
+				doc[fname] = value
+			}
+		case fname == "battery_pct":
+			doc[fname] = rng.Intn(101)
+		default:
+			doc[fname] = datagen.RandomValueWithFaker(fdef, faker, fname)
+		}
+	}
+	return doc
+}
+
+func (iotTelemetryWorkload) GenerateUpdate(rng *rand.Rand) map[string]interface{} {
+	_, _, value := randomMetric(rng)
+	return map[string]interface{}{
+		"$set": map[string]interface{}{"value": value},
+	}
+}
@@ -0,0 +1,61 @@
+package workloads
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/Percona-Lab/percona-load-generator-mongodb/internal/config"
+)
+
+// Workload generates documents/updates for one named domain scenario
+// (flights, ecommerce_orders, ...), selected via CollectionDefinition.
+// WorkloadKind instead of the collection's literal name, so new scenarios
+// can be added without touching GenerateDocument/GenerateFallbackUpdate.
+type Workload interface {
+	// Name identifies the workload, matching the string it was Register'd
+	// under and the value collections select via WorkloadKind.
+	Name() string
+	// GenerateDocument builds one document for col. rng is the caller's
+	// worker-scoped RNG, so a seeded run reproduces the same stream.
+	GenerateDocument(col config.CollectionDefinition, rng *rand.Rand) map[string]interface{}
+	// GenerateUpdate builds a fallback update document (used when no
+	// configured query covers the op) specific to this workload's schema.
+	GenerateUpdate(rng *rand.Rand) map[string]interface{}
+}
+
+// PostProcessor is an optional extra a Workload can implement for
+// cross-field fixups GenerateDocument can't express alone (e.g.
+// banking_ledger forcing "amount"'s sign to match "transaction_type").
+// GenerateDocument applies it right after GenerateDocument returns, when
+// present.
+type PostProcessor interface {
+	PostProcess(doc map[string]interface{}, rng *rand.Rand) error
+}
+
+// registry maps a WorkloadKind string to its Workload. Built-ins register
+// themselves from an init() in their own file; it is never written to
+// concurrently, since registration only happens at package init.
+var registry = map[string]Workload{}
+
+// Register adds a Workload under name, overwriting any existing entry for
+// that name. Built-ins call this from init().
+func Register(name string, w Workload) {
+	registry[name] = w
+}
+
+// Lookup returns the Workload registered under name, if any.
+func Lookup(name string) (Workload, bool) {
+	w, ok := registry[name]
+	return w, ok
+}
+
+// Registered lists every registered workload kind, sorted, for discovery
+// (e.g. a --list-workloads flag or config validation error message).
+func Registered() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}